@@ -0,0 +1,294 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"sort"
+)
+
+// The aggregate functions accumulate exactly and round only the final
+// result half up to the requested scale. ErrEmpty is returned for an empty
+// input and ErrOverflow for a result that does not fit. The NullDec versions
+// skip null values and return a null result when no value is left, as SQL
+// aggregate functions do.
+
+// cmpWide compares x and y without aligning their scales in 128 bits
+// and returns -1, 0 or +1.
+func cmpWide(x, y *Dec) int {
+	neg, m, _ := addWide(x, y, true)
+	if m.isZero() {
+		return 0
+	} else if neg {
+		return -1
+	}
+	return 1
+}
+
+// largestScale returns the largest scale of values.
+func largestScale(values []Dec) uint8 {
+	var s uint8
+	for i := range values {
+		if values[i].scale > s {
+			s = values[i].scale
+		}
+	}
+	return s
+}
+
+// sum returns the exact sum of values as c * 10**-s, where s is the
+// largest scale of the values.
+func sum(values []Dec) (c *big.Int, s uint8) {
+	s = largestScale(values)
+	c = new(big.Int)
+	for i := range values {
+		v := bigInt(&values[i].coef)
+		c.Add(c, v.Mul(v, pow10big(int(s-values[i].scale))))
+	}
+	return c, s
+}
+
+// Sum returns the sum of values.
+// The scale of the sum is the largest scale of the values.
+func Sum(values []Dec) (Dec, error) {
+	var v Dec
+	if len(values) == 0 {
+		return v, ErrEmpty
+	}
+	c, s := sum(values)
+	_, err := v.setFixed(c, int(s), s, RoundHalfUp)
+	return v, err
+}
+
+// Mean returns the arithmetic mean of values rounded to the given scale.
+func Mean(values []Dec, scale uint8) (Dec, error) {
+	var v Dec
+	if len(values) == 0 {
+		return v, ErrEmpty
+	}
+	c, s := sum(values)
+	// c * 10**(scale-s) / n
+	num, den := c.Mul(c, pow10big(int(scale))), big.NewInt(int64(len(values)))
+	den.Mul(den, pow10big(int(s)))
+	_, err := v.setFixed(quoBig(num, den, RoundHalfUp), int(scale), scale, RoundHalfUp)
+	return v, err
+}
+
+// Min returns the smallest of values.
+func Min(values []Dec) (Dec, error) {
+	if len(values) == 0 {
+		return Dec{}, ErrEmpty
+	}
+	m := values[0]
+	for i := range values[1:] {
+		if cmpWide(&values[i+1], &m) < 0 {
+			m = values[i+1]
+		}
+	}
+	return m, nil
+}
+
+// Max returns the largest of values.
+func Max(values []Dec) (Dec, error) {
+	if len(values) == 0 {
+		return Dec{}, ErrEmpty
+	}
+	m := values[0]
+	for i := range values[1:] {
+		if cmpWide(&values[i+1], &m) > 0 {
+			m = values[i+1]
+		}
+	}
+	return m, nil
+}
+
+// sorted returns a sorted copy of values.
+func sorted(values []Dec) []Dec {
+	s := append([]Dec(nil), values...)
+	sort.SliceStable(s, func(i, j int) bool {
+		return cmpWide(&s[i], &s[j]) < 0
+	})
+	return s
+}
+
+// Median returns the middle value of values, or the exact mean of the two
+// middle values for an even number of values.
+func Median(values []Dec) (Dec, error) {
+	if len(values) == 0 {
+		return Dec{}, ErrEmpty
+	}
+	s := sorted(values)
+	n := len(s)
+	if n%2 == 1 {
+		return s[n/2], nil
+	}
+	// halve exactly, with one more digit for an odd sum
+	c, sc := sum(s[n/2-1 : n/2+1])
+	if c.Bit(0) == 1 {
+		if sc == 255 {
+			return Dec{}, ErrPrecisionLoss
+		}
+		c.Mul(c, big.NewInt(5))
+		sc++
+	} else {
+		c.Rsh(c, 1)
+	}
+	var m Dec
+	_, err := m.setFixed(c, int(sc), sc, RoundHalfUp)
+	return m, err
+}
+
+// Percentile returns the p-th percentile of values, 0 <= p <= 1, rounded
+// to the given scale. Values between two ranks are linearly interpolated,
+// as by the PERCENTILE.INC spreadsheet function.
+// ErrDomain is returned if p is out of range.
+func Percentile(values []Dec, p *Dec, scale uint8) (Dec, error) {
+	if p.Sign() < 0 || cmpWide(p, decOne) > 0 {
+		return Dec{}, ErrDomain
+	}
+	if len(values) == 0 {
+		return Dec{}, ErrEmpty
+	}
+	s := sorted(values)
+	// rank = p * (n-1) = k + f
+	var rank, d, r Dec
+	if _, err := rank.MulChecked(p, New(int64(len(s)-1))); err != nil {
+		return Dec{}, err
+	}
+	k, f := rank.IntPart(), rank.FracPart()
+	i := int(k.coef.Int64())
+	if f.Sign() != 0 {
+		if _, err := d.SubChecked(&s[i+1], &s[i]); err != nil {
+			return Dec{}, err
+		}
+	}
+	if !r.fma(&f, &d, &s[i], scale, RoundHalfUp) {
+		return Dec{}, ErrOverflow
+	}
+	return r, nil
+}
+
+// variance returns the sample variance of values as the fraction num/den.
+func variance(values []Dec) (num, den *big.Int, err error) {
+	if len(values) < 2 {
+		return nil, nil, ErrEmpty
+	}
+	s := largestScale(values)
+	// (n*sum(c**2) - sum(c)**2) / (n*(n-1)*10**2s) with c aligned to scale s
+	sum, sq := new(big.Int), new(big.Int)
+	for i := range values {
+		c := bigInt(&values[i].coef)
+		c.Mul(c, pow10big(int(s-values[i].scale)))
+		sum.Add(sum, c)
+		sq.Add(sq, c.Mul(c, c))
+	}
+	n := big.NewInt(int64(len(values)))
+	num = sq.Mul(sq, n)
+	num.Sub(num, sum.Mul(sum, sum))
+	den = n.Mul(n, big.NewInt(int64(len(values)-1)))
+	den.Mul(den, pow10big(2*int(s)))
+	return num, den, nil
+}
+
+// Variance returns the sample variance of values rounded to the given
+// scale. ErrEmpty is returned for less than two values.
+func Variance(values []Dec, scale uint8) (Dec, error) {
+	num, den, err := variance(values)
+	if err != nil {
+		return Dec{}, err
+	}
+	var v Dec
+	num.Mul(num, pow10big(int(scale)))
+	_, err = v.setFixed(quoBig(num, den, RoundHalfUp), int(scale), scale, RoundHalfUp)
+	return v, err
+}
+
+// StdDev returns the sample standard deviation of values rounded to the
+// given scale. ErrEmpty is returned for less than two values.
+func StdDev(values []Dec, scale uint8) (Dec, error) {
+	num, den, err := variance(values)
+	if err != nil {
+		return Dec{}, err
+	}
+	// r = sqrt(a/b) with a = num * 10**2scale, rounded up from r + 1/2
+	a := num.Mul(num, pow10big(2*int(scale)))
+	r := new(big.Int).Quo(a, den)
+	r.Sqrt(r)
+	h := new(big.Int).Lsh(r, 1)
+	h.Add(h, big.NewInt(1))
+	h.Mul(h, h)
+	h.Mul(h, den)
+	if h.Cmp(a.Lsh(a, 2)) <= 0 {
+		r.Add(r, big.NewInt(1))
+	}
+	var v Dec
+	_, err = v.setFixed(r, int(scale), scale, RoundHalfUp)
+	return v, err
+}
+
+// nonNull returns the values that are not null.
+func nonNull(values []NullDec) []Dec {
+	var s []Dec
+	for i := range values {
+		if !values[i].Null() {
+			s = append(s, values[i].dec)
+		}
+	}
+	return s
+}
+
+// nullResult returns v as a NullDec, or a null NullDec on ErrEmpty.
+func nullResult(v Dec, err error) (NullDec, error) {
+	if err == ErrEmpty {
+		return NullDec{}, nil
+	} else if err != nil {
+		return NullDec{}, err
+	}
+	return NullDec{dec: v, valid: true}, nil
+}
+
+// SumNull returns the sum of the values that are not null.
+func SumNull(values []NullDec) (NullDec, error) {
+	return nullResult(Sum(nonNull(values)))
+}
+
+// MeanNull returns the arithmetic mean of the values that are not null
+// rounded to the given scale.
+func MeanNull(values []NullDec, scale uint8) (NullDec, error) {
+	return nullResult(Mean(nonNull(values), scale))
+}
+
+// MinNull returns the smallest of the values that are not null.
+func MinNull(values []NullDec) (NullDec, error) {
+	return nullResult(Min(nonNull(values)))
+}
+
+// MaxNull returns the largest of the values that are not null.
+func MaxNull(values []NullDec) (NullDec, error) {
+	return nullResult(Max(nonNull(values)))
+}
+
+// MedianNull returns the median of the values that are not null.
+func MedianNull(values []NullDec) (NullDec, error) {
+	return nullResult(Median(nonNull(values)))
+}
+
+// PercentileNull returns the p-th percentile of the values that are not
+// null rounded to the given scale.
+func PercentileNull(values []NullDec, p *Dec, scale uint8) (NullDec, error) {
+	return nullResult(Percentile(nonNull(values), p, scale))
+}
+
+// VarianceNull returns the sample variance of the values that are not null
+// rounded to the given scale.
+func VarianceNull(values []NullDec, scale uint8) (NullDec, error) {
+	return nullResult(Variance(nonNull(values), scale))
+}
+
+// StdDevNull returns the sample standard deviation of the values that are
+// not null rounded to the given scale.
+func StdDevNull(values []NullDec, scale uint8) (NullDec, error) {
+	return nullResult(StdDev(nonNull(values), scale))
+}
@@ -0,0 +1,185 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func parseDecs(values ...string) []Dec {
+	s := make([]Dec, len(values))
+	for i, v := range values {
+		s[i] = *parse(v)
+	}
+	return s
+}
+
+func TestAggregates(t *testing.T) {
+	values := []struct {
+		x                   []string
+		sum, mean, min, max string
+		median, vari, stdev string
+	}{
+		{[]string{"1.5", "2.25", "-3", "10"}, "10.75", "2.69", "-3", "10", "1.875", "29.140625", "5.398206"},
+		{[]string{"3", "1", "2"}, "6", "2.00", "1", "3", "2", "1.000000", "1.000000"},
+		{[]string{"0.1", "0.2"}, "0.3", "0.15", "0.1", "0.2", "0.15", "0.005000", "0.070711"},
+		{[]string{"100", "100.00", "99.99"}, "299.99", "100.00", "99.99", "100", "100", "0.000033", "0.005774"},
+		{[]string{"-5"}, "-5", "-5.00", "-5", "-5", "-5", "", ""},
+	}
+	for _, a := range values {
+		x := parseDecs(a.x...)
+		sum, err1 := Sum(x)
+		mean, err2 := Mean(x, 2)
+		min, err3 := Min(x)
+		max, err4 := Max(x)
+		median, err5 := Median(x)
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil {
+			t.Errorf("%v got errors %v %v %v %v %v", a.x, err1, err2, err3, err4, err5)
+		}
+		if sum.String() != a.sum || mean.String() != a.mean || min.String() != a.min || max.String() != a.max || median.String() != a.median {
+			t.Errorf("%v got %s %s %s %s %s want %s %s %s %s %s", a.x, sum, mean, min, max, median, a.sum, a.mean, a.min, a.max, a.median)
+		}
+		if a.vari == "" {
+			continue
+		}
+		vari, err1 := Variance(x, 6)
+		stdev, err2 := StdDev(x, 6)
+		if err1 != nil || err2 != nil || vari.String() != a.vari || stdev.String() != a.stdev {
+			t.Errorf("%v got %s %s %v %v want %s %s", a.x, vari, stdev, err1, err2, a.vari, a.stdev)
+		}
+	}
+}
+
+func TestMinMaxScales(t *testing.T) {
+	// the values cannot be aligned in 128 bits
+	x := parseDecs("0.01", "100000000000000000000000000000000000000", "-0.00000000000000000000000000000000000000001")
+	min, err1 := Min(x)
+	max, err2 := Max(x)
+	if err1 != nil || err2 != nil || min.String() != "-0.00000000000000000000000000000000000000001" || max.String() != "100000000000000000000000000000000000000" {
+		t.Errorf("got %s %s %v %v", min, max, err1, err2)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	x := parseDecs("10", "1.5", "-3", "2.25")
+	values := []struct {
+		p, r string
+	}{
+		{"0", "-3.00"},
+		{"0.1", "-1.65"},
+		{"0.5", "1.88"},
+		{"0.9", "7.68"},
+		{"1", "10.00"},
+		{"0.333333", "1.50"},
+	}
+	for _, a := range values {
+		r, err := Percentile(x, parse(a.p), 2)
+		if err != nil || r.String() != a.r {
+			t.Errorf("percentile %s got %s %v want %s", a.p, r, err, a.r)
+		}
+	}
+	if r, err := Percentile(parseDecs("7"), parse("0.3"), 1); err != nil || r.String() != "7.0" {
+		t.Errorf("percentile of one value got %s %v want 7.0", r, err)
+	}
+	for _, p := range []string{"-0.1", "1.01"} {
+		if _, err := Percentile(x, parse(p), 2); err != ErrDomain {
+			t.Errorf("percentile %s got %v want %v", p, err, ErrDomain)
+		}
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	if _, err := Sum(nil); err != ErrEmpty {
+		t.Errorf("sum of nothing got %v want %v", err, ErrEmpty)
+	}
+	if _, err := Mean(nil, 2); err != ErrEmpty {
+		t.Errorf("mean of nothing got %v want %v", err, ErrEmpty)
+	}
+	if _, err := Min(nil); err != ErrEmpty {
+		t.Errorf("min of nothing got %v want %v", err, ErrEmpty)
+	}
+	if _, err := Max(nil); err != ErrEmpty {
+		t.Errorf("max of nothing got %v want %v", err, ErrEmpty)
+	}
+	if _, err := Median(nil); err != ErrEmpty {
+		t.Errorf("median of nothing got %v want %v", err, ErrEmpty)
+	}
+	if _, err := Percentile(nil, parse("0.5"), 2); err != ErrEmpty {
+		t.Errorf("percentile of nothing got %v want %v", err, ErrEmpty)
+	}
+	if _, err := Variance(parseDecs("1"), 2); err != ErrEmpty {
+		t.Errorf("variance of one value got %v want %v", err, ErrEmpty)
+	}
+	if _, err := StdDev(parseDecs("1"), 2); err != ErrEmpty {
+		t.Errorf("stddev of one value got %v want %v", err, ErrEmpty)
+	}
+	const max = "170141183460469231731687303715884105727"
+	big := parseDecs(max, "1")
+	if _, err := Sum(big); err != ErrOverflow {
+		t.Errorf("sum got %v want %v", err, ErrOverflow)
+	}
+	if _, err := Mean(parseDecs(max), 1); err != ErrOverflow {
+		t.Errorf("mean got %v want %v", err, ErrOverflow)
+	}
+	if _, err := Median(parseDecs(max, "0")); err != ErrOverflow {
+		t.Errorf("median got %v want %v", err, ErrOverflow)
+	}
+	// only the result has to fit
+	if s, err := Sum(parseDecs(max, "1", "-1")); err != nil || s.String() != max {
+		t.Errorf("sum got %s %v want %s", s, err, max)
+	}
+	if m, err := Mean(parseDecs(max, max), 0); err != nil || m.String() != max {
+		t.Errorf("mean got %s %v want %s", m, err, max)
+	}
+	if m, err := Mean(big, 0); err != nil || m.String() != "85070591730234615865843651857942052864" {
+		t.Errorf("mean got %s %v", m, err)
+	}
+	if m, err := Median(big); err != nil || m.String() != "85070591730234615865843651857942052864" {
+		t.Errorf("median got %s %v", m, err)
+	}
+	if _, err := Variance(big, 0); err != ErrOverflow {
+		t.Errorf("variance got %v want %v", err, ErrOverflow)
+	}
+}
+
+func TestNullAggregates(t *testing.T) {
+	var x [4]NullDec
+	x[0].SetString("1")
+	x[2].SetString("4")
+	x[3].SetString("")
+	values := x[:]
+	sum, err1 := SumNull(values)
+	mean, err2 := MeanNull(values, 1)
+	min, err3 := MinNull(values)
+	max, err4 := MaxNull(values)
+	median, err5 := MedianNull(values)
+	perc, err6 := PercentileNull(values, parse("0.5"), 1)
+	vari, err7 := VarianceNull(values, 1)
+	stdev, err8 := StdDevNull(values, 1)
+	for _, err := range []error{err1, err2, err3, err4, err5, err6, err7, err8} {
+		if err != nil {
+			t.Errorf("got error %v", err)
+		}
+	}
+	got := []NullDec{sum, mean, min, max, median, perc, vari, stdev}
+	want := []string{"5", "2.5", "1", "4", "2.5", "2.5", "4.5", "2.1"}
+	for i := range got {
+		if got[i].String() != want[i] {
+			t.Errorf("aggregate %d got %s want %s", i, got[i], want[i])
+		}
+	}
+	if s, err := SumNull(x[3:]); err != nil || !s.Null() {
+		t.Errorf("sum of null got %s %v want null", s, err)
+	}
+	if s, err := VarianceNull(x[2:], 1); err != nil || !s.Null() {
+		t.Errorf("variance of one value got %s %v want null", s, err)
+	}
+	x[1].SetString("170141183460469231731687303715884105727")
+	if _, err := SumNull(values); err != ErrOverflow {
+		t.Errorf("sum got %v want %v", err, ErrOverflow)
+	}
+	x[2].SetString("-4")
+	if m, err := MeanNull(values, 0); err != nil || m.String() != "56713727820156410577229101238628035241" {
+		t.Errorf("mean got %s %v", m, err)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "sort"
+
+// Allocate divides total into parts proportional to ratios, each with
+// the given scale, and returns the parts. Total is first rounded half up to
+// the given scale and the parts always add up to it exactly.
+//
+// The largest remainder method is used: every part is truncated and the
+// remaining units of the last digit go one each to the parts with the
+// largest discarded remainders, the earlier part first on ties.
+//
+// If ratios is empty, a ratio is negative or all ratios are zero,
+// Allocate panics with Invalid allocation ratios.
+func Allocate(total *Dec, ratios []*Dec, scale uint8) []Dec {
+	var sum Dec
+	for _, r := range ratios {
+		if r.Sign() < 0 {
+			panic("Invalid allocation ratios")
+		}
+		sum.Add(&sum, r)
+	}
+	if sum.Sign() == 0 {
+		panic("Invalid allocation ratios")
+	}
+	var t Dec
+	t.Quantize(total, scale, RoundHalfUp)
+	neg := t.Sign() < 0
+	t.Abs(&t)
+
+	parts := make([]Dec, len(ratios))
+	rems := make([]Dec, len(ratios))
+	left := t
+	for i, r := range ratios {
+		var p, q Dec
+		p.Mul(&t, r)
+		parts[i].DivMode(&p, &sum, scale, RoundDown)
+		q.Mul(&parts[i], &sum)
+		rems[i].Sub(&p, &q)
+		left.Sub(&left, &parts[i])
+	}
+
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return rems[order[a]].Cmp(&rems[order[b]]) > 0
+	})
+	unit := Dec{coef: Int128{lo: 1}, scale: scale}
+	for _, i := range order[:left.coef.Int64()] {
+		parts[i].Add(&parts[i], &unit)
+	}
+	if neg {
+		for i := range parts {
+			parts[i].Neg(&parts[i])
+		}
+	}
+	return parts
+}
+
+// Split divides total into n equal parts, each with the given scale, and
+// returns the parts. Total is first rounded half up to the given scale and
+// the parts always add up to it exactly, the earlier parts receiving the
+// remaining units of the last digit.
+// If n is not positive, Split panics with Invalid allocation ratios.
+func Split(total *Dec, n int, scale uint8) []Dec {
+	if n <= 0 {
+		panic("Invalid allocation ratios")
+	}
+	ratios := make([]*Dec, n)
+	for i := range ratios {
+		ratios[i] = decOne
+	}
+	return Allocate(total, ratios, scale)
+}
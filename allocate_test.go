@@ -0,0 +1,81 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"strings"
+	"testing"
+)
+
+func joinDecs(parts []Dec) string {
+	s := make([]string, len(parts))
+	for i, p := range parts {
+		s[i] = p.String()
+	}
+	return strings.Join(s, " ")
+}
+
+func TestAllocate(t *testing.T) {
+	values := []struct {
+		total  string
+		ratios []string
+		scale  uint8
+		r      string
+	}{
+		{"100.00", []string{"1", "2", "7"}, 2, "10.00 20.00 70.00"},
+		{"100.00", []string{"1", "1", "1"}, 2, "33.34 33.33 33.33"},
+		{"0.05", []string{"3", "7"}, 2, "0.02 0.03"},
+		{"0.05", []string{"0.3", "0.7"}, 2, "0.02 0.03"},
+		{"0.05", []string{"0.2", "0.7"}, 2, "0.01 0.04"},
+		{"-100.00", []string{"1", "1", "1"}, 2, "-33.34 -33.33 -33.33"},
+		{"10", []string{"1", "0", "1"}, 0, "5 0 5"},
+		{"1", []string{"1", "1", "1", "1", "1", "1"}, 1, "0.2 0.2 0.2 0.2 0.1 0.1"},
+		{"100", []string{"33.3", "33.3", "33.4"}, 2, "33.30 33.30 33.40"},
+		{"0.01", []string{"1", "1"}, 2, "0.01 0.00"},
+		{"1000.005", []string{"1", "2"}, 2, "333.34 666.67"},
+		{"0", []string{"1", "2"}, 2, "0.00 0.00"},
+	}
+	for _, a := range values {
+		ratios := make([]*Dec, len(a.ratios))
+		for i, r := range a.ratios {
+			ratios[i] = parse(r)
+		}
+		parts := Allocate(parse(a.total), ratios, a.scale)
+		if r := joinDecs(parts); r != a.r {
+			t.Errorf("allocate %s by %v got %s want %s", a.total, a.ratios, r, a.r)
+		}
+	}
+	for _, ratios := range [][]*Dec{nil, {New(0), New(0)}, {New(1), New(-1)}} {
+		if "Invalid allocation ratios" != panics(func() {
+			Allocate(New(1), ratios, 2)
+		}) {
+			t.Errorf("allocate by %v failed to panic", ratios)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	values := []struct {
+		total string
+		n     int
+		scale uint8
+		r     string
+	}{
+		{"100.00", 3, 2, "33.34 33.33 33.33"},
+		{"100", 4, 0, "25 25 25 25"},
+		{"-0.05", 3, 2, "-0.02 -0.02 -0.01"},
+		{"7", 1, 2, "7.00"},
+	}
+	for _, a := range values {
+		if r := joinDecs(Split(parse(a.total), a.n, a.scale)); r != a.r {
+			t.Errorf("split %s in %d got %s want %s", a.total, a.n, r, a.r)
+		}
+	}
+	if "Invalid allocation ratios" != panics(func() {
+		Split(New(1), 0, 2)
+	}) {
+		t.Errorf("split in 0 failed to panic")
+	}
+}
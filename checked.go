@@ -0,0 +1,222 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+// The checked methods return ErrOverflow, ErrDivisionByZero or
+// ErrPrecisionLoss instead of panicking. On error the receiver is left
+// unchanged.
+
+// AddChecked sets z to the sum x+y and returns z.
+func (z *Int128) AddChecked(x, y *Int128) (*Int128, error) {
+	var t Int128
+	if !t.add(x, y) {
+		return z, ErrOverflow
+	}
+	*z = t
+	return z, nil
+}
+
+// SubChecked sets z to the difference x-y and returns z.
+func (z *Int128) SubChecked(x, y *Int128) (*Int128, error) {
+	var t Int128
+	if !t.sub(x, y) {
+		return z, ErrOverflow
+	}
+	*z = t
+	return z, nil
+}
+
+// MulChecked sets z to the product x*y and returns z.
+func (z *Int128) MulChecked(x, y *Int128) (*Int128, error) {
+	var t Int128
+	if !t.mul(x, y) {
+		return z, ErrOverflow
+	}
+	*z = t
+	return z, nil
+}
+
+// DivModChecked sets z to the quotient x/y and r to the modulus x%y
+// and returns the pair (z, r).
+func (z *Int128) DivModChecked(x, y, r *Int128) (*Int128, *Int128, error) {
+	if y.Sign() == 0 {
+		return z, r, ErrDivisionByZero
+	}
+	z.DivMod(x, y, r)
+	return z, r, nil
+}
+
+// AddChecked sets d to the sum x+y and returns d.
+// The scale of d is the larger of the scales of the two operands.
+func (d *Dec) AddChecked(x, y *Dec) (*Dec, error) {
+	dx, dy, err := maxscaleChecked(x, y)
+	if err != nil {
+		return d, err
+	}
+	var z Int128
+	if !z.add(&dx.coef, &dy.coef) {
+		return d, ErrOverflow
+	}
+	d.coef = z
+	d.scale = dx.scale
+	return d, nil
+}
+
+// SubChecked sets d to the difference x-y and returns d.
+// The scale of d is the larger of the scales of the two operands.
+func (d *Dec) SubChecked(x, y *Dec) (*Dec, error) {
+	dx, dy, err := maxscaleChecked(x, y)
+	if err != nil {
+		return d, err
+	}
+	var z Int128
+	if !z.sub(&dx.coef, &dy.coef) {
+		return d, ErrOverflow
+	}
+	d.coef = z
+	d.scale = dx.scale
+	return d, nil
+}
+
+// MulChecked sets d to the product x*y and returns d.
+// The scale of d is the sum of the scales of the two operands,
+// ErrPrecisionLoss is returned if it does not fit in an uint8.
+func (d *Dec) MulChecked(x, y *Dec) (*Dec, error) {
+	if int(x.scale)+int(y.scale) > 255 {
+		return d, ErrPrecisionLoss
+	}
+	var z Int128
+	if !z.mul(&x.coef, &y.coef) {
+		return d, ErrOverflow
+	}
+	d.coef = z
+	d.scale = x.scale + y.scale
+	return d, nil
+}
+
+// MulRoundChecked sets d to the product x*y rounded to the given scale
+// according to mode and returns d.
+func (d *Dec) MulRoundChecked(x, y *Dec, scale uint8, mode RoundingMode) (*Dec, error) {
+	neg, m, s := mulWide(x, y)
+	if !d.setScaled(neg, &m, s, scale, mode) {
+		return d, ErrOverflow
+	}
+	return d, nil
+}
+
+// DivChecked sets d to the rounded quotient x/y and returns d.
+// The resulting value is rounded half up to the given scale.
+func (d *Dec) DivChecked(x, y *Dec, scale uint8) (*Dec, error) {
+	return d.DivModeChecked(x, y, scale, RoundHalfUp)
+}
+
+// DivModeChecked sets d to the quotient x/y rounded to the given scale
+// according to mode and returns d.
+func (d *Dec) DivModeChecked(x, y *Dec, scale uint8, mode RoundingMode) (*Dec, error) {
+	return d, d.quo(x, y, scale, mode)
+}
+
+// QuantizeChecked sets d to x with exactly the given scale and returns d.
+// If scale is less than the scale of x the value is rounded according
+// to mode, otherwise it is padded with zeros.
+func (d *Dec) QuantizeChecked(x *Dec, scale uint8, mode RoundingMode) (*Dec, error) {
+	var m uint256
+	m.setInt128(&x.coef)
+	if !d.setScaled(x.coef.Sign() < 0, &m, int(x.scale), scale, mode) {
+		return d, ErrOverflow
+	}
+	return d, nil
+}
+
+func maxscaleChecked(x, y *Dec) (*Dec, *Dec, error) {
+	if x.scale > y.scale {
+		dy, err := y.upscale(x.scale)
+		return x, dy, err
+	}
+	dx, err := x.upscale(y.scale)
+	return dx, y, err
+}
+
+// AddChecked sets d to the sum x+y and returns d.
+func (d *NullDec) AddChecked(x, y *NullDec) (*NullDec, error) {
+	if x.Null() || y.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.AddChecked(&x.dec, &y.dec); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
+
+// SubChecked sets d to the difference x-y and returns d.
+func (d *NullDec) SubChecked(x, y *NullDec) (*NullDec, error) {
+	if x.Null() || y.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.SubChecked(&x.dec, &y.dec); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
+
+// MulChecked sets d to the product x*y and returns d.
+func (d *NullDec) MulChecked(x, y *NullDec) (*NullDec, error) {
+	if x.Null() || y.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.MulChecked(&x.dec, &y.dec); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
+
+// MulRoundChecked sets d to the product x*y rounded to the given scale
+// according to mode and returns d.
+func (d *NullDec) MulRoundChecked(x, y *NullDec, scale uint8, mode RoundingMode) (*NullDec, error) {
+	if x.Null() || y.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.MulRoundChecked(&x.dec, &y.dec, scale, mode); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
+
+// DivChecked sets d to the rounded quotient x/y and returns d.
+// The resulting value is rounded half up to the given scale.
+func (d *NullDec) DivChecked(x, y *NullDec, scale uint8) (*NullDec, error) {
+	return d.DivModeChecked(x, y, scale, RoundHalfUp)
+}
+
+// DivModeChecked sets d to the quotient x/y rounded to the given scale
+// according to mode and returns d.
+func (d *NullDec) DivModeChecked(x, y *NullDec, scale uint8, mode RoundingMode) (*NullDec, error) {
+	if x.Null() || y.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.DivModeChecked(&x.dec, &y.dec, scale, mode); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
+
+// QuantizeChecked sets d to x with exactly the given scale and returns d.
+// If scale is less than the scale of x the value is rounded according
+// to mode, otherwise it is padded with zeros.
+func (d *NullDec) QuantizeChecked(x *NullDec, scale uint8, mode RoundingMode) (*NullDec, error) {
+	if x.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.QuantizeChecked(&x.dec, scale, mode); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestIntChecked(t *testing.T) {
+	max := Int128{math.MaxUint64, math.MaxInt64}
+	min := Int128{0, math.MinInt64}
+	var z Int128
+	z.SetInt64(7)
+	if _, err := z.AddChecked(&max, intOne); err != ErrOverflow {
+		t.Errorf("add overflow got %v", err)
+	}
+	if _, err := z.SubChecked(&min, intOne); err != ErrOverflow {
+		t.Errorf("sub overflow got %v", err)
+	}
+	if _, err := z.MulChecked(&max, intTen); err != ErrOverflow {
+		t.Errorf("mul overflow got %v", err)
+	}
+	var r Int128
+	if _, _, err := z.DivModChecked(intOne, &r, &r); err != ErrDivisionByZero {
+		t.Errorf("division by zero got %v", err)
+	}
+	if z.Int64() != 7 {
+		t.Errorf("failed checked operation alters z to %s", z)
+	}
+	if _, err := z.AddChecked(intTen, intOne); err != nil || z.Int64() != 11 {
+		t.Errorf("10 + 1 got %s %v", z, err)
+	}
+	if _, err := z.SubChecked(intTen, intOne); err != nil || z.Int64() != 9 {
+		t.Errorf("10 - 1 got %s %v", z, err)
+	}
+	if _, err := z.MulChecked(intTen, intTen); err != nil || z.Int64() != 100 {
+		t.Errorf("10 * 10 got %s %v", z, err)
+	}
+	if _, _, err := z.DivModChecked(&z, &Int128{7, 0}, &r); err != nil || z.Int64() != 14 || r.Int64() != 2 {
+		t.Errorf("100 / 7 got %s %s %v", z, r, err)
+	}
+}
+
+func TestChecked(t *testing.T) {
+	values := []struct {
+		op    string
+		x, y  string
+		scale uint8
+		r     string
+		err   error
+	}{
+		{"+", "1.2", "0.03", 0, "1.23", nil},
+		{"+", "170141183460469231731687303715884105727", "1", 0, "", ErrOverflow},
+		{"+", "17014118346046923173168730371588410573", "0.1", 0, "", ErrOverflow},
+		{"-", "1.2", "0.03", 0, "1.17", nil},
+		{"-", "-170141183460469231731687303715884105727", "2", 0, "", ErrOverflow},
+		{"*", "1.5", "1.5", 0, "2.25", nil},
+		{"*", "10000000000000000000", "100000000000000000000", 0, "", ErrOverflow},
+		{"r", "1.25", "1.25", 2, "1.56", nil},
+		{"r", "10000000000000000000", "100000000000000000000", 0, "", ErrOverflow},
+		{"r", "10000000000000000000", "0.00000000000000000001", 2, "0.10", nil},
+		{"/", "1", "3", 2, "0.33", nil},
+		{"/", "1", "0", 2, "", ErrDivisionByZero},
+		{"/", "100000000000000000000", "3", 20, "", ErrOverflow},
+		{"q", "1.25", "0", 1, "1.3", nil},
+		{"q", "-1.25", "0", 3, "-1.250", nil},
+		{"q", "17014118346046923173168730371588410573", "0", 1, "", ErrOverflow},
+		{"q", "1", "0", 40, "", ErrOverflow},
+	}
+	for _, a := range values {
+		var x, y, z Dec
+		x.SetString(a.x)
+		y.SetString(a.y)
+		var err error
+		switch a.op {
+		case "+":
+			_, err = z.AddChecked(&x, &y)
+		case "-":
+			_, err = z.SubChecked(&x, &y)
+		case "*":
+			_, err = z.MulChecked(&x, &y)
+		case "r":
+			_, err = z.MulRoundChecked(&x, &y, a.scale, RoundHalfUp)
+		case "/":
+			_, err = z.DivChecked(&x, &y, a.scale)
+		case "q":
+			_, err = z.QuantizeChecked(&x, a.scale, RoundHalfUp)
+		}
+		if err != a.err {
+			t.Errorf("%s %s %s got error %v want %v", a.x, a.op, a.y, err, a.err)
+		}
+		if err == nil && z.String() != a.r {
+			t.Errorf("%s %s %s got %s want %s", a.x, a.op, a.y, z, a.r)
+		}
+		if err != nil && z.String() != "0" {
+			t.Errorf("%s %s %s alters z to %s", a.x, a.op, a.y, z)
+		}
+	}
+
+	x := Dec{coef: *intOne, scale: 200}
+	var z Dec
+	if _, err := z.MulChecked(&x, &x); err != ErrPrecisionLoss {
+		t.Errorf("scale overflow got %v", err)
+	}
+}
+
+func TestNullChecked(t *testing.T) {
+	var x, y, z NullDec
+	x.SetString("1")
+	if _, err := z.AddChecked(&x, &y); err != nil || !z.Null() {
+		t.Errorf("1 + null got %s %v", z, err)
+	}
+	y.SetString("0")
+	if _, err := z.DivChecked(&x, &y, 2); err != ErrDivisionByZero {
+		t.Errorf("1 / 0 got %v", err)
+	}
+	y.SetString("4")
+	if _, err := z.DivChecked(&x, &y, 2); err != nil || z.String() != "0.25" {
+		t.Errorf("1 / 4 got %s %v", z, err)
+	}
+	if _, err := z.SubChecked(&x, &y); err != nil || z.String() != "-3" {
+		t.Errorf("1 - 4 got %s %v", z, err)
+	}
+	if _, err := z.MulChecked(&x, &y); err != nil || z.String() != "4" {
+		t.Errorf("1 * 4 got %s %v", z, err)
+	}
+	if _, err := z.MulRoundChecked(&x, &y, 1, RoundHalfUp); err != nil || z.String() != "4.0" {
+		t.Errorf("1 * 4 got %s %v", z, err)
+	}
+	if _, err := z.QuantizeChecked(&y, 2, RoundHalfUp); err != nil || z.String() != "4.00" {
+		t.Errorf("quantize 4 got %s %v", z, err)
+	}
+}
+
+func TestConditionIs(t *testing.T) {
+	var err error = Overflow | Inexact
+	if !errors.Is(err, ErrOverflow) || !errors.Is(err, ErrPrecisionLoss) || errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("errors.Is failed for %s", err)
+	}
+}
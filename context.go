@@ -0,0 +1,192 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "strings"
+
+// MaxPrecision is the maximum number of significant digits of a Context.
+const MaxPrecision = 38
+
+// Condition is a set of exceptional conditions raised by Context operations.
+type Condition uint32
+
+const (
+	Overflow         Condition = 1 << iota // result does not fit in 128 bits
+	Inexact                                // non zero digits were discarded
+	Rounded                                // digits were discarded
+	DivisionByZero                         // non zero dividend divided by zero
+	InvalidOperation                       // invalid operands or context
+)
+
+var conditionNames = [...]string{
+	"overflow",
+	"inexact",
+	"rounded",
+	"division by zero",
+	"invalid operation",
+}
+
+// String returns the names of the conditions in c.
+func (c Condition) String() string {
+	var names []string
+	for i, name := range conditionNames {
+		if c&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Error implements the error interface for trapped conditions.
+func (c Condition) Error() string {
+	return "decimal: " + c.String()
+}
+
+// Is reports whether c contains the condition matching target, so that
+// errors.Is(err, ErrOverflow) holds for a trapped Overflow.
+func (c Condition) Is(target error) bool {
+	switch target {
+	case ErrOverflow:
+		return c&Overflow != 0
+	case ErrDivisionByZero:
+		return c&DivisionByZero != 0
+	case ErrPrecisionLoss:
+		return c&Inexact != 0
+	case ErrDomain:
+		return c&InvalidOperation != 0
+	}
+	return false
+}
+
+// Context holds the precision and the rounding mode of arithmetic
+// operations, the conditions that are trapped and the conditions that
+// have been raised.
+//
+// Results are computed exactly and then rounded once to at most Precision
+// significant digits. Raised conditions accumulate in Flags; those also in
+// Traps are returned as an error. On Overflow, DivisionByZero and
+// InvalidOperation the result is left unchanged.
+//
+// A Context must not be used concurrently.
+type Context struct {
+	Precision uint8        // significant digits, 0 means MaxPrecision
+	Rounding  RoundingMode // rounding of discarded digits
+	Traps     Condition    // conditions returned as errors
+	Flags     Condition    // conditions raised so far
+}
+
+// NewContext returns a Context with the given precision and rounding mode
+// that traps Overflow, DivisionByZero and InvalidOperation.
+func NewContext(precision uint8, mode RoundingMode) *Context {
+	return &Context{
+		Precision: precision,
+		Rounding:  mode,
+		Traps:     Overflow | DivisionByZero | InvalidOperation,
+	}
+}
+
+func (c *Context) prec() (int, bool) {
+	if c.Precision == 0 {
+		return MaxPrecision, true
+	}
+	return int(c.Precision), c.Precision <= MaxPrecision
+}
+
+// raise adds cond to the flags of c and returns z
+// and the trapped conditions as an error.
+func (c *Context) raise(z *Dec, cond Condition) (*Dec, error) {
+	c.Flags |= cond
+	if t := cond & c.Traps; t != 0 {
+		return z, t
+	}
+	return z, nil
+}
+
+func (c *Context) set(z *Dec, neg bool, m *uint256, scale int) (*Dec, error) {
+	prec, ok := c.prec()
+	if !ok {
+		return c.raise(z, InvalidOperation)
+	}
+	return c.raise(z, z.setWide(neg, m, scale, prec, c.Rounding))
+}
+
+// Round sets z to x rounded to the precision of c and returns z.
+func (c *Context) Round(z, x *Dec) (*Dec, error) {
+	var m uint256
+	m.setInt128(&x.coef)
+	return c.set(z, x.coef.Sign() < 0, &m, int(x.scale))
+}
+
+// Add sets z to the sum x+y rounded to the precision of c and returns z.
+func (c *Context) Add(z, x, y *Dec) (*Dec, error) {
+	neg, m, scale := addWide(x, y, false)
+	return c.set(z, neg, &m, scale)
+}
+
+// Sub sets z to the difference x-y rounded to the precision of c
+// and returns z.
+func (c *Context) Sub(z, x, y *Dec) (*Dec, error) {
+	neg, m, scale := addWide(x, y, true)
+	return c.set(z, neg, &m, scale)
+}
+
+// Mul sets z to the product x*y rounded to the precision of c
+// and returns z.
+func (c *Context) Mul(z, x, y *Dec) (*Dec, error) {
+	neg, m, s := mulWide(x, y)
+	return c.set(z, neg, &m, s)
+}
+
+// Div sets z to the quotient x/y rounded to the precision of c
+// and returns z. An exact quotient has its trailing zeros removed down
+// to the scale of x less the scale of y.
+func (c *Context) Div(z, x, y *Dec) (*Dec, error) {
+	if y.coef.Sign() == 0 {
+		if x.coef.Sign() == 0 {
+			return c.raise(z, InvalidOperation)
+		}
+		return c.raise(z, DivisionByZero)
+	}
+	prec, ok := c.prec()
+	if !ok {
+		return c.raise(z, InvalidOperation)
+	}
+	var n, d, q, r uint256
+	n.setInt128(&x.coef)
+	d.setInt128(&y.coef)
+	// scale the dividend up to 77 digits
+	a := len(pow10w) - 1 - n.digits()
+	n.mul(&n, &pow10w[a])
+	q.divmod(&n, &d, &r)
+	for !r.isZero() && q.digits() <= prec {
+		j := prec + 1 - q.digits()
+		if j > MaxPrecision {
+			j = MaxPrecision
+		}
+		var t uint256
+		r.mul(&r, &pow10w[j])
+		t.divmod(&r, &d, &r)
+		q.mul(&q, &pow10w[j])
+		q.add(&q, &t)
+		a += j
+	}
+	scale := a + int(x.scale) - int(y.scale)
+	if r.isZero() {
+		ideal := int(x.scale) - int(y.scale)
+		for scale > ideal && scale > 0 {
+			var t uint256
+			t.divmod(&q, &pow10w[1], &r)
+			if !r.isZero() {
+				break
+			}
+			q = t
+			scale--
+		}
+	} else {
+		q.jam()
+	}
+	neg := (x.coef.Sign() < 0) != (y.coef.Sign() < 0)
+	return c.set(z, neg, &q, scale)
+}
@@ -0,0 +1,126 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func TestContext(t *testing.T) {
+	values := []struct {
+		op    string
+		x, y  string
+		prec  uint8
+		mode  RoundingMode
+		r     string
+		flags Condition
+	}{
+		{"+", "1.2", "3.45", 5, RoundHalfUp, "4.65", 0},
+		{"+", "123.45", "0.005", 5, RoundHalfUp, "123.46", Rounded | Inexact},
+		{"+", "123.45", "0.005", 5, RoundHalfEven, "123.46", Rounded | Inexact},
+		{"+", "123.44", "0.005", 5, RoundHalfEven, "123.44", Rounded | Inexact},
+		{"+", "99999", "1", 5, RoundHalfUp, "100000", Rounded},
+		{"+", "9.9999", "0.00005", 5, RoundHalfUp, "10.000", Rounded | Inexact},
+		{"+", "1", "0.0000000000000000000000000000000000000000000000001", 0, RoundHalfUp,
+			"1.0000000000000000000000000000000000000", Rounded | Inexact},
+		{"+", "1", "0.0000000000000000000000000000000000000000000000001", 0, RoundUp,
+			"1.0000000000000000000000000000000000001", Rounded | Inexact},
+		{"-", "1", "0.0000000000000000000000000000000000000000000000001", 0, RoundDown,
+			"0.99999999999999999999999999999999999999", Rounded | Inexact},
+		{"-", "1.00", "0.01", 2, RoundHalfUp, "0.99", 0},
+		{"-", "123456", "0.5", 3, RoundHalfUp, "123000", Rounded | Inexact},
+		{"*", "1.23", "4.56", 3, RoundHalfUp, "5.61", Rounded | Inexact},
+		{"*", "1.23", "4.56", 3, RoundDown, "5.60", Rounded | Inexact},
+		{"*", "-1.23", "4.56", 3, RoundFloor, "-5.61", Rounded | Inexact},
+		{"*", "123456789012345678.90123456789", "98765432109876543.210987654321", 0, RoundHalfUp,
+			"12193263113702179522618503273362292.333", Rounded | Inexact},
+		{"/", "1", "3", 5, RoundHalfUp, "0.33333", Rounded | Inexact},
+		{"/", "2", "3", 5, RoundHalfUp, "0.66667", Rounded | Inexact},
+		{"/", "-2", "3", 5, RoundDown, "-0.66666", Rounded | Inexact},
+		{"/", "1", "4", 5, RoundHalfUp, "0.25", 0},
+		{"/", "1.00", "4", 5, RoundHalfUp, "0.25", 0},
+		{"/", "100", "4", 5, RoundHalfUp, "25", 0},
+		{"/", "1", "0.01", 5, RoundHalfUp, "100", 0},
+		{"/", "0", "3", 5, RoundHalfUp, "0", 0},
+		{"/", "12345678901234567890", "0.0000000003", 0, RoundHalfUp,
+			"41152263004115226300000000000", 0},
+		{"/", "1", "7", 0, RoundHalfEven, "0.14285714285714285714285714285714285714", Rounded | Inexact},
+		{"round", "2.5", "", 1, RoundHalfEven, "2", Rounded | Inexact},
+		{"round", "12.5", "", 5, RoundHalfEven, "12.5", 0},
+	}
+	for _, a := range values {
+		var x, y, z Dec
+		x.SetString(a.x)
+		if a.y != "" {
+			y.SetString(a.y)
+		}
+		c := NewContext(a.prec, a.mode)
+		var err error
+		switch a.op {
+		case "+":
+			_, err = c.Add(&z, &x, &y)
+		case "-":
+			_, err = c.Sub(&z, &x, &y)
+		case "*":
+			_, err = c.Mul(&z, &x, &y)
+		case "/":
+			_, err = c.Div(&z, &x, &y)
+		case "round":
+			_, err = c.Round(&z, &x)
+		}
+		if err != nil {
+			t.Errorf("%s %s %s: %s", a.x, a.op, a.y, err)
+		}
+		if z.String() != a.r || c.Flags != a.flags {
+			t.Errorf("%s %s %s prec %d %s got %s [%s] want %s [%s]",
+				a.x, a.op, a.y, a.prec, a.mode, z, c.Flags, a.r, a.flags)
+		}
+	}
+}
+
+func TestContextTraps(t *testing.T) {
+	var x, y, z Dec
+	x.SetString("1")
+	z.SetString("7")
+	c := NewContext(5, RoundHalfUp)
+	_, err := c.Div(&z, &x, &y)
+	if err != DivisionByZero || z.String() != "7" {
+		t.Errorf("1 / 0 got %s %v", z, err)
+	}
+	_, err = c.Div(&z, &y, &y)
+	if err != InvalidOperation || c.Flags != DivisionByZero|InvalidOperation {
+		t.Errorf("0 / 0 got %v flags %s", err, c.Flags)
+	}
+
+	x.SetString("170141183460469231731687303715884105727")
+	c = NewContext(0, RoundHalfUp)
+	_, err = c.Add(&z, &x, &x)
+	if err != Overflow {
+		t.Errorf("overflow add got %v", err)
+	}
+	c.Traps = 0
+	_, err = c.Mul(&z, &x, &x)
+	if err != nil || c.Flags&Overflow == 0 {
+		t.Errorf("untrapped overflow got %v flags %s", err, c.Flags)
+	}
+
+	c = NewContext(4, RoundHalfUp)
+	c.Traps |= Inexact
+	x.SetString("1")
+	y.SetString("3")
+	_, err = c.Div(&z, &x, &y)
+	if err != Inexact || z.String() != "0.3333" {
+		t.Errorf("trapped inexact got %s %v", z, err)
+	}
+	if err.Error() != "decimal: inexact" {
+		t.Errorf("error got %q", err.Error())
+	}
+	if s := (Rounded | Inexact).String(); s != "inexact, rounded" {
+		t.Errorf("condition got %q", s)
+	}
+
+	c = NewContext(MaxPrecision+1, RoundHalfUp)
+	if _, err = c.Add(&z, &x, &y); err != InvalidOperation {
+		t.Errorf("invalid precision got %v", err)
+	}
+}
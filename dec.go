@@ -8,13 +8,25 @@ package decimal
 import (
 	"bytes"
 	"database/sql/driver"
+	"encoding/binary"
 	"errors"
+	"hash/maphash"
 	"io"
 	"math"
 	"strconv"
 	"strings"
 )
 
+// Errors returned by the checked arithmetic, the mathematical and the
+// aggregate functions.
+var (
+	ErrOverflow       = errors.New("Arithmetic overflow")
+	ErrDivisionByZero = errors.New("Division by zero")
+	ErrPrecisionLoss  = errors.New("loss of precision")
+	ErrDomain         = errors.New("Argument out of domain")
+	ErrEmpty          = errors.New("Empty input")
+)
+
 // Dec is represented as an 128 bit integer scaled by a power of ten.
 type Dec struct {
 	coef  Int128
@@ -82,17 +94,29 @@ func exp10(scale uint8) *Int128 {
 }
 
 func (d *Dec) rescale(scale uint8) *Dec {
+	z, err := d.upscale(scale)
+	if err != nil {
+		panic(err.Error())
+	}
+	return z
+}
+
+// upscale returns d with the given scale that must not be less than
+// the scale of d.
+func (d *Dec) upscale(scale uint8) (*Dec, error) {
 	if scale == d.scale {
-		return d
+		return d, nil
 	} else if scale < d.scale {
-		panic("loss of precision")
+		return nil, ErrPrecisionLoss
 	}
-	z := d.coef
-	z.Mul(&z, exp10(scale-d.scale))
-	return &Dec{
-		coef:  z,
-		scale: scale,
+	z := Dec{scale: scale}
+	if d.coef.Sign() == 0 {
+		return &z, nil
 	}
+	if scale-d.scale > MaxPrecision || !z.coef.mul(&d.coef, exp10(scale-d.scale)) {
+		return nil, ErrOverflow
+	}
+	return &z, nil
 }
 
 func maxscale(x, y *Dec) (*Dec, *Dec) {
@@ -164,58 +188,270 @@ func (d *Dec) Mul(x, y *Dec) *Dec {
 	return d
 }
 
+// MulRound sets d to the product x*y rounded to the given scale according
+// to mode and returns d. The product is computed in 256 bits so that only
+// a rounded result that does not fit panics with Arithmetic overflow.
+func (d *Dec) MulRound(x, y *Dec, scale uint8, mode RoundingMode) *Dec {
+	if _, err := d.MulRoundChecked(x, y, scale, mode); err != nil {
+		panic(err.Error())
+	}
+	return d
+}
+
 // Div sets d to the rounded quotient x/y and returns d.
 // If y is zero panics with Division by zero.
 // The resulting value is rounded half up to the given scale.
 func (d *Dec) Div(x, y *Dec, scale uint8) *Dec {
+	return d.DivMode(x, y, scale, RoundHalfUp)
+}
+
+// DivMode sets d to the quotient x/y rounded to the given scale
+// according to mode and returns d.
+// If y is zero panics with Division by zero.
+func (d *Dec) DivMode(x, y *Dec, scale uint8, mode RoundingMode) *Dec {
+	if err := d.quo(x, y, scale, mode); err != nil {
+		panic(err.Error())
+	}
+	return d
+}
+
+// quo sets d to the quotient x/y rounded to the given scale according
+// to mode. The operands are aligned in 256 bits so that only a quotient
+// that does not fit fails. On error d is left unchanged.
+func (d *Dec) quo(x, y *Dec, scale uint8, mode RoundingMode) error {
+	if y.coef.Sign() == 0 {
+		return ErrDivisionByZero
+	}
+	neg := (x.coef.Sign() < 0) != (y.coef.Sign() < 0)
+	var n, v, q uint256
+	n.setInt128(&x.coef)
+	v.setInt128(&y.coef)
 	shift := int(scale) - int(x.scale) + int(y.scale)
-	var sx, sy *Int128
-	if shift > 0 {
-		var z Int128
-		sx = z.Mul(&x.coef, exp10(uint8(shift)))
-		sy = &y.coef
+	if shift > 0 && !n.isZero() {
+		if shift >= len(pow10w) || !n.mul(&n, &pow10w[shift]) {
+			// the quotient exceeds 2**129
+			return ErrOverflow
+		}
 	} else if shift < 0 {
-		sx = &x.coef
-		var z Int128
-		sy = z.Mul(&y.coef, exp10(uint8(-shift)))
-	} else {
-		sx = &x.coef
-		sy = &y.coef
+		if -shift >= len(pow10w) || !v.mul(&v, &pow10w[-shift]) {
+			// the quotient is less than 2**-129
+			v = uint256{}
+		}
 	}
-	d.scale = scale
-	var r Int128
-	d.coef.DivMod(sx, sy, &r)
-	var roundUp bool
-	if r.Sign() != 0 {
-		r.Abs(&r)
-		var v Int128
-		v.Abs(sy)
-		roundUp = r.Add(&r, &r).Cmp(&v) >= 0
-	}
-	if roundUp {
-		if d.coef.Sign() < 0 {
-			d.coef.Sub(&d.coef, intOne)
-		} else {
-			d.coef.Add(&d.coef, intOne)
+	if v.isZero() {
+		if !n.isZero() && mode.away(neg, false, -1, false) {
+			q[0] = 1
 		}
+	} else {
+		q.quoRound(&n, &v, neg, mode)
 	}
-	return d
+	coef, ok := q.int128(neg)
+	if !ok {
+		return ErrOverflow
+	}
+	d.coef = coef
+	d.scale = scale
+	return nil
 }
 
 // Round d half up to the given scale and returns d
 func (d *Dec) Round(scale uint8) *Dec {
+	return d.RoundMode(scale, RoundHalfUp)
+}
+
+// RoundMode rounds d to the given scale according to mode and returns d.
+// If the scale of d is not larger than scale, d is unchanged.
+func (d *Dec) RoundMode(scale uint8, mode RoundingMode) *Dec {
+	if d.scale <= scale {
+		return d
+	}
+	return d.Quantize(d, scale, mode)
+}
+
+// Trunc truncates d toward zero to the given scale and returns d.
+// If the scale of d is not larger than scale, d is unchanged.
+func (d *Dec) Trunc(scale uint8) *Dec {
+	return d.truncate(scale, 0)
+}
+
+// Ceil rounds d toward +∞ to the given scale and returns d.
+// If the scale of d is not larger than scale, d is unchanged.
+func (d *Dec) Ceil(scale uint8) *Dec {
+	return d.truncate(scale, 1)
+}
+
+// Floor rounds d toward -∞ to the given scale and returns d.
+// If the scale of d is not larger than scale, d is unchanged.
+func (d *Dec) Floor(scale uint8) *Dec {
+	return d.truncate(scale, -1)
+}
+
+// truncate truncates d to the given scale and, if the discarded digits
+// have the sign dir, moves the result one unit in that direction.
+func (d *Dec) truncate(scale uint8, dir int) *Dec {
+	if d.scale <= scale {
+		return d
+	}
+	q, r := d.split(scale)
+	if dir > 0 && r.Sign() > 0 {
+		q.Add(&q, intOne)
+	} else if dir < 0 && r.Sign() < 0 {
+		q.Sub(&q, intOne)
+	}
+	d.coef = q
+	d.scale = scale
+	return d
+}
+
+// split returns the quotient and the remainder, truncated toward zero,
+// of the coefficient of d divided by 10**(d.scale-scale).
+func (d *Dec) split(scale uint8) (q, r Int128) {
 	if d.scale <= scale {
+		return d.coef, r
+	}
+	n := d.scale - scale
+	if n > MaxPrecision {
+		// the coefficient is less than 10**n
+		return q, d.coef
+	}
+	q.DivMod(&d.coef, exp10(n), &r)
+	return q, r
+}
+
+// IsInteger reports whether d has no fractional part.
+func (d Dec) IsInteger() bool {
+	_, r := d.split(0)
+	return r.Sign() == 0
+}
+
+// IntPart returns the integer part of d, truncated toward zero, with scale 0.
+func (d Dec) IntPart() Dec {
+	q, _ := d.split(0)
+	return Dec{coef: q}
+}
+
+// FracPart returns the fractional part of d, that has the sign and the
+// scale of d.
+func (d Dec) FracPart() Dec {
+	_, r := d.split(0)
+	return Dec{coef: r, scale: d.scale}
+}
+
+// Quantize sets d to x with exactly the given scale and returns d.
+// If scale is less than the scale of x the value is rounded according
+// to mode, otherwise it is padded with zeros.
+func (d *Dec) Quantize(x *Dec, scale uint8, mode RoundingMode) *Dec {
+	if _, err := d.QuantizeChecked(x, scale, mode); err != nil {
+		panic(err.Error())
+	}
+	return d
+}
+
+// SetScale sets d to its value with exactly the given scale, rounding
+// half up if the scale is reduced, and returns d.
+func (d *Dec) SetScale(scale uint8) *Dec {
+	return d.Quantize(d, scale, RoundHalfUp)
+}
+
+// Scale returns the number of decimal digits of d after the decimal point.
+func (d Dec) Scale() uint8 {
+	return d.scale
+}
+
+// Coefficient returns the unscaled value of d,
+// that is d multiplied by 10**d.Scale().
+func (d Dec) Coefficient() Int128 {
+	return d.coef
+}
+
+// Precision returns the number of digits of the coefficient of d,
+// trailing zeros included. The precision of zero is 0.
+func (d Dec) Precision() int {
+	var m uint256
+	m.setInt128(&d.coef)
+	return m.digits()
+}
+
+// RoundSig rounds d to n significant digits according to mode and
+// returns d. Digits left of the decimal point are replaced by zeros,
+// so 123456 rounded to 3 significant digits is 123000.
+// If d has at most n digits, d is unchanged.
+// If n is zero panics with Invalid precision.
+func (d *Dec) RoundSig(n uint8, mode RoundingMode) *Dec {
+	if n == 0 {
+		panic("Invalid precision")
+	}
+	var m uint256
+	m.setInt128(&d.coef)
+	if m.digits() <= int(n) {
 		return d
 	}
-	return d.Div(d, decOne, scale)
+	if d.setWide(d.coef.Sign() < 0, &m, int(d.scale), int(n), mode)&Overflow != 0 {
+		overflow()
+	}
+	return d
 }
 
-// Power sets d = x**n and returns d
+// Reduce removes the trailing zeros after the decimal point of d
+// and returns d.
+func (d *Dec) Reduce() *Dec {
+	if d.coef.Sign() == 0 {
+		d.scale = 0
+		return d
+	}
+	for d.scale > 0 {
+		var q, r Int128
+		q.DivMod(&d.coef, intTen, &r)
+		if r.Sign() != 0 {
+			break
+		}
+		d.coef = q
+		d.scale--
+	}
+	return d
+}
+
+// Equal reports whether x and y have the same value regardless of scale.
+func (x Dec) Equal(y *Dec) bool {
+	return x.Key() == y.Key()
+}
+
+// Key returns the canonical form of d with the trailing zeros removed.
+// Numerically equal values have equal keys, so Key can be used for
+// map keys and deduplication.
+func (d Dec) Key() Dec {
+	return *d.Reduce()
+}
+
+// Hash writes the canonical form of d to h, so that numerically equal
+// values have equal hashes.
+func (d Dec) Hash(h *maphash.Hash) {
+	k := d.Key()
+	var b [17]byte
+	binary.LittleEndian.PutUint64(b[0:], k.coef.lo)
+	binary.LittleEndian.PutUint64(b[8:], uint64(k.coef.hi))
+	b[16] = k.scale
+	h.Write(b[:])
+}
+
+// Power sets d = x**n and returns d.
+// Intermediate results are rounded to scale 18, PowerScale
+// returns a correctly rounded result.
+// A negative n gives 1/x**-n correctly rounded to the scale of x plus -n,
+// or to scale 18 if that is larger, as long as it fits. An exact result
+// keeps the scale of x plus -n.
 func (d *Dec) Power(x *Dec, n int) *Dec {
+	return d.PowerMode(x, n, RoundHalfUp)
+}
+
+// PowerMode sets d = x**n and returns d.
+// Intermediate and negative exponent results are rounded according to mode.
+func (d *Dec) PowerMode(x *Dec, n int, mode RoundingMode) *Dec {
 	if n < 0 {
-		scale := x.scale
-		d.Power(x, -n)
-		return d.Div(decOne, d, scale-uint8(n))
+		if err := d.powerNeg(x, n, mode); err != nil {
+			panic(err.Error())
+		}
+		return d
 	} else if n == 0 {
 		return d.Set(decOne)
 	} else if n == 1 {
@@ -223,21 +459,65 @@ func (d *Dec) Power(x *Dec, n int) *Dec {
 	} else if (n & 1) == 0 { // n even
 		d.Mul(x, x)
 		if d.scale > 18 {
-			d.Round(18)
+			d.RoundMode(18, mode)
 		}
-		return d.Power(d, n/2)
+		return d.PowerMode(d, n/2, mode)
 	}
 	// n odd
 	var z Dec
 	z.Set(x)
 	d.Mul(x, x)
 	if d.scale > 18 {
-		d.Round(18)
+		d.RoundMode(18, mode)
 	}
-	d.Power(d, (n-1)/2)
+	d.PowerMode(d, (n-1)/2, mode)
 	return d.Mul(d, &z)
 }
 
+// Sqrt sets d to the square root of x rounded to the given scale
+// according to mode and returns d.
+// If x is negative, Sqrt panics.
+func (d *Dec) Sqrt(x *Dec, scale uint8, mode RoundingMode) *Dec {
+	if x.coef.Sign() < 0 {
+		panic("Square root of negative number")
+	}
+	// the radicand must have an even scale not less than the scale of x
+	s := int(scale)
+	if t := (int(x.scale) + 1) / 2; t > s {
+		s = t
+	}
+	k := 2*s - int(x.scale)
+	var m, q, r uint256
+	m.setInt128(&x.coef)
+	if !m.isZero() && (k >= len(pow10w) || !m.mul(&m, &pow10w[k])) {
+		overflow()
+	}
+	q.sqrt(&m, &r)
+	if s == int(scale) {
+		// the root is never exactly halfway: q*q + q is below (q+0.5)**2,
+		// so it is above half only if r > q
+		half := -1
+		if r.cmp(&q) > 0 {
+			half = 1
+		}
+		if mode.away(false, q[0]&1 == 1, half, r.isZero()) {
+			q.add(&q, &uint256{1})
+		}
+	} else {
+		if !r.isZero() {
+			q.jam()
+		}
+		q.quoPow10(&q, s-int(scale), false, mode)
+	}
+	coef, ok := q.int128(false)
+	if !ok {
+		overflow()
+	}
+	d.coef = coef
+	d.scale = scale
+	return d
+}
+
 // String returns the value of d
 func (d Dec) String() string {
 	return string(d.Bytes())
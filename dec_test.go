@@ -5,7 +5,9 @@
 package decimal
 
 import (
+	"hash/maphash"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -259,6 +261,37 @@ func TestMul(t *testing.T) {
 	}
 }
 
+func TestMulRound(t *testing.T) {
+	values := []struct {
+		x, y  string
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"1.25", "0.5", 2, RoundHalfUp, "0.63"},
+		{"1.25", "0.5", 2, RoundHalfEven, "0.62"},
+		{"-1.25", "0.5", 2, RoundFloor, "-0.63"},
+		{"1.2", "3", 4, RoundHalfUp, "3.6000"},
+		{"12345678.9012345678", "98765432.1098765432", 4, RoundHalfUp, "1219326311370217.9432"},
+		{"1234567890123456789.0123456789", "1234567890.0123456789", 2, RoundDown, "1524157875186709466268861467.62"},
+		{"0.00000000000000000001", "0.00000000000000000001", 30, RoundUp, "0.000000000000000000000000000001"},
+		{"0", "12345678901234567890.1234567890", 200, RoundHalfUp, "0." + strings.Repeat("0", 200)},
+	}
+	for _, a := range values {
+		var z Dec
+		z.MulRound(parse(a.x), parse(a.y), a.scale, a.mode)
+		if z.String() != a.r {
+			t.Errorf("%s * %s scale %d %s got %s want %s", a.x, a.y, a.scale, a.mode, z, a.r)
+		}
+	}
+	if "Arithmetic overflow" != panics(func() {
+		var z Dec
+		z.MulRound(parse("12345678901234567890.1"), parse("98765432109876543210.9"), 0, RoundHalfUp)
+	}) {
+		t.Errorf("failed to panic with overflow")
+	}
+}
+
 func TestRound(t *testing.T) {
 	values := []struct {
 		x     string
@@ -281,6 +314,208 @@ func TestRound(t *testing.T) {
 	}
 }
 
+func TestTruncCeilFloor(t *testing.T) {
+	values := []struct {
+		x                  string
+		scale              uint8
+		trunc, ceil, floor string
+	}{
+		{"1.234", 2, "1.23", "1.24", "1.23"},
+		{"-1.234", 2, "-1.23", "-1.23", "-1.24"},
+		{"1.230", 2, "1.23", "1.23", "1.23"},
+		{"-1.230", 2, "-1.23", "-1.23", "-1.23"},
+		{"0.999", 0, "0", "1", "0"},
+		{"-0.999", 0, "0", "0", "-1"},
+		{"-0.001", 1, "0.0", "0.0", "-0.1"},
+		{"12.5", 3, "12.5", "12.5", "12.5"},
+		{"0." + strings.Repeat("0", 49) + "1", 2, "0.00", "0.01", "0.00"},
+		{"-0." + strings.Repeat("0", 49) + "1", 2, "0.00", "0.00", "-0.01"},
+		{"170141183460469231731687303715884105.727", 0, "170141183460469231731687303715884105", "170141183460469231731687303715884106", "170141183460469231731687303715884105"},
+	}
+	for _, a := range values {
+		x := parse(a.x)
+		if x.Trunc(a.scale); x.String() != a.trunc {
+			t.Errorf("trunc %s %d got %s want %s", a.x, a.scale, x, a.trunc)
+		}
+		x = parse(a.x)
+		if x.Ceil(a.scale); x.String() != a.ceil {
+			t.Errorf("ceil %s %d got %s want %s", a.x, a.scale, x, a.ceil)
+		}
+		x = parse(a.x)
+		if x.Floor(a.scale); x.String() != a.floor {
+			t.Errorf("floor %s %d got %s want %s", a.x, a.scale, x, a.floor)
+		}
+	}
+}
+
+func TestIntFracPart(t *testing.T) {
+	values := []struct {
+		x         string
+		integer   bool
+		int, frac string
+	}{
+		{"12.34", false, "12", "0.34"},
+		{"-12.34", false, "-12", "-0.34"},
+		{"12.00", true, "12", "0.00"},
+		{"-0.5", false, "0", "-0.5"},
+		{"7", true, "7", "0"},
+		{"0.000", true, "0", "0.000"},
+		{"0." + strings.Repeat("0", 49) + "1", false, "0", "0." + strings.Repeat("0", 49) + "1"},
+	}
+	for _, a := range values {
+		x := parse(a.x)
+		i, f := x.IntPart(), x.FracPart()
+		if x.IsInteger() != a.integer || i.String() != a.int || f.String() != a.frac {
+			t.Errorf("%s got %v %s %s want %v %s %s", a.x, x.IsInteger(), i, f, a.integer, a.int, a.frac)
+		}
+	}
+}
+
+func TestRoundSig(t *testing.T) {
+	values := []struct {
+		x    string
+		n    uint8
+		mode RoundingMode
+		r    string
+		prec int
+	}{
+		{"0.000123456", 3, RoundHalfUp, "0.000123", 6},
+		{"123456", 3, RoundHalfUp, "123000", 6},
+		{"123556", 3, RoundHalfUp, "124000", 6},
+		{"-123456", 3, RoundFloor, "-124000", 6},
+		{"1.2345", 4, RoundHalfEven, "1.234", 5},
+		{"1.2355", 4, RoundHalfEven, "1.236", 5},
+		{"9.995", 3, RoundHalfUp, "10.0", 4},
+		{"999.5", 3, RoundHalfUp, "1000", 4},
+		{"99999", 1, RoundHalfUp, "100000", 5},
+		{"1.20", 3, RoundHalfUp, "1.20", 3},
+		{"12", 5, RoundHalfUp, "12", 2},
+		{"0.00", 2, RoundHalfUp, "0.00", 0},
+		{"170141183460469231731687303715884105727", 38, RoundDown, "170141183460469231731687303715884105720", 39},
+	}
+	for _, a := range values {
+		x := parse(a.x)
+		if p := x.Precision(); p != a.prec {
+			t.Errorf("precision %s got %d want %d", a.x, p, a.prec)
+		}
+		if x.RoundSig(a.n, a.mode); x.String() != a.r {
+			t.Errorf("%s to %d significant digits %s got %s want %s", a.x, a.n, a.mode, x, a.r)
+		}
+	}
+	if "Arithmetic overflow" != panics(func() {
+		parse("170141183460469231731687303715884105727").RoundSig(1, RoundUp)
+	}) {
+		t.Errorf("failed to panic with overflow")
+	}
+	if "Invalid precision" != panics(func() {
+		New(1).RoundSig(0, RoundUp)
+	}) {
+		t.Errorf("failed to panic with invalid precision")
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	values := []struct {
+		x     string
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"1.5", 3, RoundHalfUp, "1.500"},
+		{"-1.5", 3, RoundHalfUp, "-1.500"},
+		{"1.5", 1, RoundHalfUp, "1.5"},
+		{"1.2345", 2, RoundHalfUp, "1.23"},
+		{"1.235", 2, RoundHalfEven, "1.24"},
+		{"1.245", 2, RoundHalfEven, "1.24"},
+		{"-1.2301", 2, RoundFloor, "-1.24"},
+		{"1.2301", 2, RoundCeiling, "1.24"},
+		{"19.99", 0, RoundDown, "19"},
+		{"0.000000000000000000000000000000000000000000000001", 2, RoundHalfUp, "0.00"},
+		{"0.000000000000000000000000000000000000000000000001", 2, RoundUp, "0.01"},
+		{"0", 4, RoundHalfUp, "0.0000"},
+	}
+	for _, a := range values {
+		var x, z Dec
+		x.SetString(a.x)
+		z.Quantize(&x, a.scale, a.mode)
+		if z.String() != a.r || z.Scale() != a.scale {
+			t.Errorf("quantize %s to %d %s got %s want %s", a.x, a.scale, a.mode, z, a.r)
+		}
+		x.SetString(a.x)
+		if a.mode == RoundHalfUp && x.SetScale(a.scale).String() != a.r {
+			t.Errorf("set scale of %s to %d got %s want %s", a.x, a.scale, x, a.r)
+		}
+	}
+}
+
+func TestCoefficient(t *testing.T) {
+	var d Dec
+	d.SetString("-12.345")
+	c := d.Coefficient()
+	if c.Int64() != -12345 || d.Scale() != 3 {
+		t.Errorf("got coefficient %s scale %d", c, d.Scale())
+	}
+}
+
+func TestReduce(t *testing.T) {
+	values := []struct {
+		x string
+		r string
+	}{
+		{"1.500", "1.5"},
+		{"-1.500", "-1.5"},
+		{"10.00", "10"},
+		{"100", "100"},
+		{"0.000", "0"},
+		{"0.0010", "0.001"},
+	}
+	for _, a := range values {
+		var x Dec
+		x.SetString(a.x)
+		if x.Reduce().String() != a.r {
+			t.Errorf("reduce %s got %s want %s", a.x, x, a.r)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	values := []struct {
+		x, y string
+		eq   bool
+	}{
+		{"1.0", "1", true},
+		{"1.10", "1.1", true},
+		{"0.00", "0", true},
+		{"-2.50", "-2.5", true},
+		{"1.01", "1.1", false},
+		{"-1", "1", false},
+		{"1", "0.000000000000000000000000000000000000000000000000000000001", false},
+	}
+	seed := maphash.MakeSeed()
+	for _, a := range values {
+		var x, y Dec
+		x.SetString(a.x)
+		y.SetString(a.y)
+		if x.Equal(&y) != a.eq {
+			t.Errorf("%s == %s got %v want %v", a.x, a.y, !a.eq, a.eq)
+		}
+		if (x.Key() == y.Key()) != a.eq {
+			t.Errorf("key %s == %s got %v want %v", a.x, a.y, !a.eq, a.eq)
+		}
+		var hx, hy maphash.Hash
+		hx.SetSeed(seed)
+		hy.SetSeed(seed)
+		x.Hash(&hx)
+		y.Hash(&hy)
+		if a.eq && hx.Sum64() != hy.Sum64() {
+			t.Errorf("hash %s != %s", a.x, a.y)
+		}
+		if x.String() != a.x {
+			t.Errorf("key of %s alters it to %s", a.x, x)
+		}
+	}
+}
+
 func TestDiv(t *testing.T) {
 	values := []struct {
 		x, y  string
@@ -317,6 +552,76 @@ func TestDiv(t *testing.T) {
 	}
 }
 
+func TestDivMode(t *testing.T) {
+	values := []struct {
+		x, y  string
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"1", "8", 2, RoundHalfUp, "0.13"},
+		{"1", "8", 2, RoundHalfEven, "0.12"},
+		{"3", "8", 2, RoundHalfEven, "0.38"},
+		{"1", "8", 2, RoundHalfDown, "0.12"},
+		{"2", "3", 2, RoundDown, "0.66"},
+		{"-2", "3", 2, RoundDown, "-0.66"},
+		{"1", "3", 2, RoundUp, "0.34"},
+		{"-1", "3", 2, RoundUp, "-0.34"},
+		{"1", "3", 2, RoundCeiling, "0.34"},
+		{"-1", "3", 2, RoundCeiling, "-0.33"},
+		{"1", "3", 2, RoundFloor, "0.33"},
+		{"1", "-3", 2, RoundFloor, "-0.34"},
+		{"1.00", "4", 1, RoundHalfEven, "0.2"},
+		{"1.0", "0.04", 0, RoundHalfEven, "25"},
+		{"123456789012345678901234567890", "7", 9, RoundHalfUp, "17636684144620811271604938270.000000000"},
+		{"123456789012345678901234567890.12", "98765432109876543210", 28, RoundHalfEven, "1249999988.6093750001548828123842789649"},
+		{"1", "3", 38, RoundDown, "0.33333333333333333333333333333333333333"},
+		{"1", "100000000000000000000000000000000000000", 2, RoundUp, "0.01"},
+		{"-1", "100000000000000000000000000000000000000", 2, RoundHalfUp, "0.00"},
+		{"0.0000000000000000000000000000000000000000000000000000000000001", "0.0000000000000000000000000000000000000000000000000000000000003", 3, RoundHalfUp, "0.333"},
+		{"0." + strings.Repeat("0", 99) + "1", "3", 2, RoundUp, "0.01"},
+		{"-0." + strings.Repeat("0", 99) + "1", "3", 2, RoundFloor, "-0.01"},
+		{"0." + strings.Repeat("0", 99) + "1", "3", 2, RoundHalfUp, "0.00"},
+		{"0", "0.3", 200, RoundUp, "0." + strings.Repeat("0", 200)},
+	}
+	for _, a := range values {
+		var x, y, z Dec
+		x.SetString(a.x)
+		y.SetString(a.y)
+		z.DivMode(&x, &y, a.scale, a.mode)
+		if z.String() != a.r {
+			t.Errorf("%s / %s round %d %s got %s want %s", a.x, a.y, a.scale, a.mode, z, a.r)
+		}
+	}
+	overflows := []struct {
+		x, y  string
+		scale uint8
+	}{
+		{"1000000000000000000000000000000", "0.0000000001", 0},
+		{"1", "1", 39},
+		{"1", "3", 100},
+		{"12345678901234567890", "0.000000000000000000001", 0},
+	}
+	for _, a := range overflows {
+		if "Arithmetic overflow" != panics(func() {
+			var z Dec
+			z.Div(parse(a.x), parse(a.y), a.scale)
+		}) {
+			t.Errorf("%s / %s round %d failed to panic with overflow", a.x, a.y, a.scale)
+		}
+	}
+}
+
+func TestDivAlias(t *testing.T) {
+	var x, y Dec
+	x.SetString("1")
+	y.SetString("3")
+	y.Div(&x, &y, 2)
+	if y.String() != "0.33" {
+		t.Errorf("1 / 3 into divisor got %s want 0.33", y)
+	}
+}
+
 func TestPower(t *testing.T) {
 	values := []struct {
 		x string
@@ -351,6 +656,19 @@ func TestPower(t *testing.T) {
 		{"10", -7, "0.0000001"},
 		{"10", -8, "0.00000001"},
 		{"10", -9, "0.000000001"},
+		{"3", -1, "0.333333333333333333"},
+		{"4", -1, "0.25"},
+		{"2", -3, "0.125"},
+		{"-2", -3, "-0.125"},
+		{"0.5", -1, "2.00"},
+		{"1.05", -10, "0.613913253540759374"},
+		{"0.97", -20, "1.8389304946019026573222"},
+		{"1.000000000000000000001", -3, "0.999999999999999999997000"},
+		{"7", -40, "0." + strings.Repeat("0", 33) + "1570646"},
+		{"1.0001", -100000, "0.000045422633889328990341800229332959752396"},
+		{"0.1", -37, "1" + strings.Repeat("0", 37) + ".0"},
+		{"0.01", -19, "1" + strings.Repeat("0", 38)},
+		{"0." + strings.Repeat("0", 37) + "1", -1, "1" + strings.Repeat("0", 38)},
 	}
 	for _, a := range values {
 		var x, y Dec
@@ -363,6 +681,76 @@ func TestPower(t *testing.T) {
 			t.Errorf("%s^%d alters %s to %s", a.x, a.n, a.x, x.String())
 		}
 	}
+
+	var x Dec
+	x.SetString("0.1")
+	if "Arithmetic overflow" != panics(func() {
+		x.Power(&x, -39)
+	}) {
+		t.Errorf("0.1^-39 failed to panic with overflow")
+	}
+	if "Division by zero" != panics(func() {
+		x.Power(New(0), -1)
+	}) {
+		t.Errorf("0^-1 failed to panic with division by zero")
+	}
+	x.SetString("1.05")
+	if x.PowerMode(&x, -7, RoundDown); x.String() != "0.710681330130121570" {
+		t.Errorf("1.05^-7 into x rounded down got %s", x)
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	values := []struct {
+		x     string
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"2", 10, RoundHalfUp, "1.4142135624"},
+		{"2", 10, RoundDown, "1.4142135623"},
+		{"2", 37, RoundHalfEven, "1.4142135623730950488016887242096980786"},
+		{"2", 37, RoundFloor, "1.4142135623730950488016887242096980785"},
+		{"0.0004", 2, RoundHalfUp, "0.02"},
+		{"0.0004", 4, RoundUp, "0.0200"},
+		{"1.44", 1, RoundUp, "1.2"},
+		{"0.5", 0, RoundHalfUp, "1"},
+		{"0.5", 0, RoundDown, "0"},
+		{"12345678901234567890", 5, RoundHalfEven, "3513641828.82014"},
+		{"12345678901234567890", 5, RoundCeiling, "3513641828.82015"},
+		{"0.000000000000000000001", 20, RoundHalfUp, "0.00000000003162277660"},
+		{"0.000000000000000000001", 20, RoundUp, "0.00000000003162277661"},
+		{"99", 0, RoundHalfUp, "10"},
+		{"99", 0, RoundFloor, "9"},
+		{"0", 2, RoundUp, "0.00"},
+		{"2", 0, RoundHalfUp, "1"},
+		{"2", 0, RoundHalfEven, "1"},
+		{"2", 0, RoundUp, "2"},
+		{"6", 0, RoundHalfUp, "2"},
+		{"110", 0, RoundHalfUp, "10"},
+		{"1.10", 0, RoundHalfUp, "1"},
+		{"0.02", 1, RoundHalfUp, "0.1"},
+	}
+	for _, a := range values {
+		var x, z Dec
+		x.SetString(a.x)
+		z.Sqrt(&x, a.scale, a.mode)
+		if z.String() != a.r {
+			t.Errorf("sqrt %s scale %d %s got %s want %s", a.x, a.scale, a.mode, z, a.r)
+		}
+	}
+	if "Square root of negative number" != panics(func() {
+		var z Dec
+		z.Sqrt(New(-1), 2, RoundHalfUp)
+	}) {
+		t.Errorf("failed to panic for negative square root")
+	}
+	if "Arithmetic overflow" != panics(func() {
+		var z Dec
+		z.Sqrt(New(2), 40, RoundHalfUp)
+	}) {
+		t.Errorf("failed to overflow square root")
+	}
 }
 
 func TestFloat64(t *testing.T) {
@@ -215,6 +215,73 @@ func ExampleNullDec_Div() {
 	// 33.33
 }
 
+func ExampleDec_RoundMode() {
+	var x, y Dec
+	x.SetString("2.345")
+	y.SetString("2.355")
+	x.RoundMode(2, RoundHalfEven)
+	y.RoundMode(2, RoundHalfEven)
+	fmt.Println(x, y)
+	// Output:
+	// 2.34 2.36
+}
+
+func ExampleDec_Quantize() {
+	var x, y Dec
+	x.SetString("1.5")
+	y.SetString("2.34567")
+	x.Quantize(&x, 2, RoundHalfUp)
+	y.Quantize(&y, 2, RoundHalfUp)
+	fmt.Println(x, y)
+	// Output:
+	// 1.50 2.35
+}
+
+func ExampleDec_Key() {
+	var x, y Dec
+	x.SetString("1.50")
+	y.SetString("1.5")
+	m := map[Dec]int{}
+	m[x.Key()]++
+	m[y.Key()]++
+	fmt.Println(len(m), m[x.Key()])
+	// Output:
+	// 1 2
+}
+
+func ExampleDec_PowDec() {
+	// monthly rate equivalent to an annual rate of 5%
+	var x, y, z Dec
+	x.SetString("1.05")
+	y.Div(New(1), New(12), 20)
+	z.PowDec(&x, &y, 8)
+	z.Sub(&z, New(1))
+	fmt.Println(z)
+	// Output:
+	// 0.00407412
+}
+
+func ExampleAllocate() {
+	var total Dec
+	total.SetString("100.00")
+	fmt.Println(Split(&total, 3, 2))
+	fmt.Println(Allocate(&total, []*Dec{New(1), New(2), New(7)}, 2))
+	// Output:
+	// [33.34 33.33 33.33]
+	// [10.00 20.00 70.00]
+}
+
+func ExampleContext() {
+	c := NewContext(5, RoundHalfEven)
+	var x, y, z Dec
+	x.SetString("2")
+	y.SetString("3")
+	c.Div(&z, &x, &y)
+	fmt.Println(z, c.Flags)
+	// Output:
+	// 0.66667 decimal: inexact, rounded
+}
+
 func ExampleInt128() {
 	var i Int128
 	i.SetInt64(-1)
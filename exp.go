@@ -0,0 +1,353 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "math/big"
+
+// The transcendental functions are evaluated in fixed point big integers
+// holding the value multiplied by 10**p. The precision p is increased until
+// the approximation determines the correctly rounded result.
+
+// extra digits of the internal fixed point precision
+const guard = 60
+
+func pow10big(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// bigInt returns x as a big.Int.
+func bigInt(x *Int128) *big.Int {
+	var m uint256
+	m.setInt128(x)
+	z := new(big.Int).SetUint64(m[1])
+	z.Lsh(z, 64)
+	z.Or(z, new(big.Int).SetUint64(m[0]))
+	if x.Sign() < 0 {
+		z.Neg(z)
+	}
+	return z
+}
+
+// int128 returns x as an Int128 and reports whether it fits.
+func int128(x *big.Int) (Int128, bool) {
+	if x.BitLen() > 127 {
+		return Int128{}, false
+	}
+	var m uint256
+	a := new(big.Int).Abs(x)
+	m[0] = a.Uint64()
+	m[1] = a.Rsh(a, 64).Uint64()
+	return m.int128(x.Sign() < 0)
+}
+
+// roundBig returns x/10**n rounded according to mode.
+func roundBig(x *big.Int, n int, mode RoundingMode) *big.Int {
+	return quoBig(x, pow10big(n), mode)
+}
+
+// quoBig returns x/y rounded according to mode for y > 0.
+func quoBig(x, y *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	neg := x.Sign() < 0
+	r.Abs(r)
+	h := new(big.Int).Sub(y, r)
+	if mode.away(neg, q.Bit(0) == 1, r.Cmp(h), false) {
+		if neg {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// setApprox sets d to the value of f rounded to the given scale according
+// to mode. f(p) must return the value multiplied by 10**p with an error of
+// less than one unit. If nonneg the value is known to be non negative.
+func (d *Dec) setApprox(f func(p int) *big.Int, nonneg bool, scale uint8, mode RoundingMode) (*Dec, error) {
+	var r *big.Int
+	for g := 8; ; g *= 2 {
+		v := f(int(scale) + g)
+		lo := new(big.Int).Sub(v, big.NewInt(1))
+		hi := new(big.Int).Add(v, big.NewInt(1))
+		if nonneg && lo.Sign() < 0 {
+			lo.SetInt64(0)
+		}
+		r = roundBig(lo, g, mode)
+		if r.Cmp(roundBig(hi, g, mode)) == 0 {
+			break
+		}
+		if g >= 256 {
+			// the value is a rounding boundary, an exact result
+			r = roundBig(roundBig(v, g-1, RoundHalfEven), 1, mode)
+			break
+		}
+	}
+	return d.setFixed(r, int(scale), scale, mode)
+}
+
+// setFixed sets d to v * 10**-s rounded to the given scale according
+// to mode. ErrOverflow is returned if the result does not fit.
+func (d *Dec) setFixed(v *big.Int, s int, scale uint8, mode RoundingMode) (*Dec, error) {
+	if k := int(scale) - s; k >= 0 {
+		v = new(big.Int).Mul(v, pow10big(k))
+	} else {
+		v = roundBig(v, -k, mode)
+	}
+	coef, ok := int128(v)
+	if !ok {
+		return d, ErrOverflow
+	}
+	d.coef = coef
+	d.scale = scale
+	return d, nil
+}
+
+// atanh returns atanh(z) where z is a fixed point number with the given one.
+func atanh(z, one *big.Int) *big.Int {
+	z2 := new(big.Int).Mul(z, z)
+	z2.Quo(z2, one)
+	sum := new(big.Int).Set(z)
+	term := new(big.Int).Set(z)
+	t := new(big.Int)
+	for k := int64(3); ; k += 2 {
+		term.Mul(term, z2)
+		term.Quo(term, one)
+		t.Quo(term, big.NewInt(k))
+		if t.Sign() == 0 {
+			break
+		}
+		sum.Add(sum, t)
+	}
+	return sum
+}
+
+// ln2 returns ln(2) * 10**q.
+func ln2(q int) *big.Int {
+	one := pow10big(q)
+	z := new(big.Int).Quo(one, big.NewInt(3))
+	z = atanh(z, one)
+	return z.Lsh(z, 1)
+}
+
+// ln10 returns ln(10) * 10**q.
+func ln10(q int) *big.Int {
+	// ln(10) = 3 ln(2) + ln(1.25)
+	one := pow10big(q)
+	z := new(big.Int).Quo(one, big.NewInt(9))
+	z = atanh(z, one)
+	z.Lsh(z, 1)
+	return z.Add(z, new(big.Int).Mul(ln2(q), big.NewInt(3)))
+}
+
+// lnFixed returns ln(c * 10**-s) * 10**q for c > 0.
+func lnFixed(c *big.Int, s, q int) *big.Int {
+	one := pow10big(q)
+	// c * 10**-s = m * 10**e with m in [1, 10)
+	n := len(c.String())
+	e := n - 1 - s
+	m := new(big.Int).Mul(c, pow10big(q+guard))
+	m.Quo(m, pow10big(n-1+guard))
+	// m = 2**j * t with t in [0.75, 1.5)
+	j := 0
+	for _, b := range []int64{15, 30, 60} {
+		if m.Cmp(new(big.Int).Quo(new(big.Int).Mul(one, big.NewInt(b)), big.NewInt(10))) >= 0 {
+			j++
+		}
+	}
+	m.Rsh(m, uint(j))
+	// ln t = 2 atanh((t-1)/(t+1))
+	num := new(big.Int).Sub(m, one)
+	num.Mul(num, one)
+	z := num.Quo(num, new(big.Int).Add(m, one))
+	r := atanh(z, one)
+	r.Lsh(r, 1)
+	if j != 0 {
+		r.Add(r, new(big.Int).Mul(ln2(q), big.NewInt(int64(j))))
+	}
+	if e != 0 {
+		r.Add(r, new(big.Int).Mul(ln10(q), big.NewInt(int64(e))))
+	}
+	return r
+}
+
+// expFixed returns exp(c * 10**-s) * 10**q for |c * 10**-s| < 1000.
+func expFixed(c *big.Int, s, q int) *big.Int {
+	// exp(x) = exp(x / 2**n) ** (2**n) with |x / 2**n| < 2**-8
+	ip := new(big.Int).Abs(c)
+	ip.Quo(ip, pow10big(s))
+	n := ip.BitLen() + 8
+	// each squaring doubles the relative error
+	w := q + guard
+	one := pow10big(w)
+	r := new(big.Int).Mul(c, one)
+	r.Quo(r, new(big.Int).Lsh(pow10big(s), uint(n)))
+	sum := new(big.Int).Set(one)
+	term := new(big.Int).Set(one)
+	for k := int64(1); ; k++ {
+		term.Mul(term, r)
+		term.Quo(term, one)
+		term.Quo(term, big.NewInt(k))
+		if term.Sign() == 0 {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	for i := 0; i < n; i++ {
+		sum.Mul(sum, sum)
+		sum.Quo(sum, one)
+	}
+	return sum.Quo(sum, pow10big(guard))
+}
+
+// Exp sets d to e**x rounded to the given scale according to mode
+// and returns d. ErrOverflow is returned if the result does not fit.
+func (d *Dec) Exp(x *Dec, scale uint8, mode RoundingMode) (*Dec, error) {
+	if x.coef.Sign() == 0 {
+		return d.Quantize(decOne, scale, mode), nil
+	}
+	c := bigInt(&x.coef)
+	s := int(x.scale)
+	// e**90 exceeds the largest Int128
+	if c.Cmp(new(big.Int).Mul(big.NewInt(90), pow10big(s))) > 0 {
+		return d, ErrOverflow
+	}
+	// e**x < 10**-(scale+2) when x < -2.31 * (scale+2)
+	t := new(big.Int).Mul(big.NewInt(-231*(int64(scale)+2)), pow10big(s))
+	if new(big.Int).Mul(c, big.NewInt(100)).Cmp(t) < 0 {
+		d.coef = Int128{}
+		if mode.away(false, false, -1, false) {
+			d.coef.lo = 1
+		}
+		d.scale = scale
+		return d, nil
+	}
+	return d.setApprox(func(p int) *big.Int {
+		return roundBig(expFixed(c, s, p+guard), guard, RoundHalfEven)
+	}, true, scale, mode)
+}
+
+// Ln sets d to the natural logarithm of x rounded to the given scale
+// according to mode and returns d. ErrDomain is returned if x <= 0.
+func (d *Dec) Ln(x *Dec, scale uint8, mode RoundingMode) (*Dec, error) {
+	if x.coef.Sign() <= 0 {
+		return d, ErrDomain
+	}
+	if x.Equal(decOne) {
+		return d.Quantize(&Dec{}, scale, mode), nil
+	}
+	c := bigInt(&x.coef)
+	s := int(x.scale)
+	return d.setApprox(func(p int) *big.Int {
+		return roundBig(lnFixed(c, s, p+guard), guard, RoundHalfEven)
+	}, false, scale, mode)
+}
+
+// Log10 sets d to the decimal logarithm of x rounded to the given scale
+// according to mode and returns d. ErrDomain is returned if x <= 0.
+func (d *Dec) Log10(x *Dec, scale uint8, mode RoundingMode) (*Dec, error) {
+	if x.coef.Sign() <= 0 {
+		return d, ErrDomain
+	}
+	var m uint256
+	m.setInt128(&x.coef)
+	if n := m.digits() - 1; m.cmp(&pow10w[n]) == 0 {
+		// exact power of ten
+		return d.Quantize(New(int64(n)-int64(x.scale)), scale, mode), nil
+	}
+	c := bigInt(&x.coef)
+	s := int(x.scale)
+	return d.setApprox(func(p int) *big.Int {
+		q := p + guard
+		r := lnFixed(c, s, q)
+		r.Mul(r, pow10big(p))
+		return r.Quo(r, ln10(q))
+	}, false, scale, mode)
+}
+
+// Log sets d to the logarithm of x in the given base rounded to the given
+// scale according to mode and returns d. ErrDomain is returned if x <= 0,
+// base <= 0 or base == 1.
+func (d *Dec) Log(x, base *Dec, scale uint8, mode RoundingMode) (*Dec, error) {
+	if x.coef.Sign() <= 0 || base.coef.Sign() <= 0 || base.Equal(decOne) {
+		return d, ErrDomain
+	}
+	if x.Equal(decOne) {
+		return d.Quantize(&Dec{}, scale, mode), nil
+	}
+	c, s := bigInt(&x.coef), int(x.scale)
+	b, bs := bigInt(&base.coef), int(base.scale)
+	return d.setApprox(func(p int) *big.Int {
+		// a logarithm of the base close to zero amplifies the error
+		q := p + guard
+		lb := lnFixed(b, bs, q)
+		for {
+			k := q - len(new(big.Int).Abs(lb).String())
+			if k <= 0 || q >= p+guard+2*k {
+				break
+			}
+			q = p + guard + 2*k
+			lb = lnFixed(b, bs, q)
+		}
+		r := lnFixed(c, s, q)
+		r.Mul(r, pow10big(p))
+		return r.Quo(r, lb)
+	}, false, scale, mode)
+}
+
+// Exp sets d to e**x rounded to the given scale according to mode
+// and returns d.
+func (d *NullDec) Exp(x *NullDec, scale uint8, mode RoundingMode) (*NullDec, error) {
+	if x.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.Exp(&x.dec, scale, mode); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
+
+// Ln sets d to the natural logarithm of x rounded to the given scale
+// according to mode and returns d.
+func (d *NullDec) Ln(x *NullDec, scale uint8, mode RoundingMode) (*NullDec, error) {
+	if x.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.Ln(&x.dec, scale, mode); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
+
+// Log10 sets d to the decimal logarithm of x rounded to the given scale
+// according to mode and returns d.
+func (d *NullDec) Log10(x *NullDec, scale uint8, mode RoundingMode) (*NullDec, error) {
+	if x.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.Log10(&x.dec, scale, mode); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
+
+// Log sets d to the logarithm of x in the given base rounded to the given
+// scale according to mode and returns d.
+func (d *NullDec) Log(x, base *NullDec, scale uint8, mode RoundingMode) (*NullDec, error) {
+	if x.Null() || base.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.Log(&x.dec, &base.dec, scale, mode); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func parse(s string) *Dec {
+	var d Dec
+	if err := d.SetString(s); err != nil {
+		panic(err)
+	}
+	return &d
+}
+
+func TestExp(t *testing.T) {
+	values := []struct {
+		x     string
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"1", 37, RoundHalfEven, "2.7182818284590452353602874713526624978"},
+		{"1", 10, RoundDown, "2.7182818284"},
+		{"-1", 20, RoundHalfUp, "0.36787944117144232160"},
+		{"0.05", 10, RoundHalfUp, "1.0512710964"},
+		{"88", 0, RoundHalfUp, "165163625499400185552832979626485876707"},
+		{"-50", 25, RoundUp, "0.0000000000000000000001929"},
+		{"2.302585092994045684", 10, RoundHalfUp, "10.0000000000"},
+		{"0", 2, RoundHalfUp, "1.00"},
+		{"-100", 10, RoundHalfUp, "0.0000000000"},
+		{"-100", 10, RoundUp, "0.0000000001"},
+	}
+	for _, a := range values {
+		var x, z Dec
+		x.SetString(a.x)
+		if _, err := z.Exp(&x, a.scale, a.mode); err != nil || z.String() != a.r {
+			t.Errorf("exp %s scale %d %s got %s %v want %s", a.x, a.scale, a.mode, z, err, a.r)
+		}
+	}
+	for _, x := range []string{"89", "1000"} {
+		var z Dec
+		if _, err := z.Exp(parse(x), 0, RoundHalfUp); err != ErrOverflow {
+			t.Errorf("exp %s got %v want %v", x, err, ErrOverflow)
+		}
+	}
+}
+
+func TestLn(t *testing.T) {
+	values := []struct {
+		x     string
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"2", 37, RoundHalfEven, "0.6931471805599453094172321214581765681"},
+		{"10", 20, RoundHalfUp, "2.30258509299404568402"},
+		{"0.5", 20, RoundFloor, "-0.69314718055994530942"},
+		{"1234567.891", 15, RoundHalfUp, "14.026231580989927"},
+		{"0.000000000000000000000000000001", 30, RoundHalfUp, "-69.077552789821370520539743640531"},
+		{"170141183460469231731687303715884105727", 30, RoundDown, "88.029691931113054295988479425188"},
+		{"1.000", 3, RoundUp, "0.000"},
+	}
+	for _, a := range values {
+		var x, z Dec
+		x.SetString(a.x)
+		if _, err := z.Ln(&x, a.scale, a.mode); err != nil || z.String() != a.r {
+			t.Errorf("ln %s scale %d %s got %s %v want %s", a.x, a.scale, a.mode, z, err, a.r)
+		}
+	}
+	for _, x := range []string{"0", "-1"} {
+		var z Dec
+		if _, err := z.Ln(parse(x), 2, RoundHalfUp); err != ErrDomain {
+			t.Errorf("ln %s got %v want %v", x, err, ErrDomain)
+		}
+	}
+}
+
+func TestLog10(t *testing.T) {
+	values := []struct {
+		x     string
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"2", 30, RoundHalfUp, "0.301029995663981195213738894724"},
+		{"0.02", 20, RoundCeiling, "-1.69897000433601880478"},
+		{"1000", 3, RoundHalfUp, "3.000"},
+		{"0.001", 0, RoundHalfUp, "-3"},
+		{"1.00", 1, RoundHalfUp, "0.0"},
+	}
+	for _, a := range values {
+		var x, z Dec
+		x.SetString(a.x)
+		if _, err := z.Log10(&x, a.scale, a.mode); err != nil || z.String() != a.r {
+			t.Errorf("log10 %s scale %d %s got %s %v want %s", a.x, a.scale, a.mode, z, err, a.r)
+		}
+	}
+	var z Dec
+	if _, err := z.Log10(New(0), 2, RoundHalfUp); err != ErrDomain {
+		t.Errorf("log10 0 got %v want %v", err, ErrDomain)
+	}
+}
+
+func TestLog(t *testing.T) {
+	values := []struct {
+		x, base string
+		scale   uint8
+		mode    RoundingMode
+		r       string
+	}{
+		{"8", "2", 5, RoundHalfUp, "3.00000"},
+		{"8", "4", 0, RoundHalfUp, "2"},
+		{"8", "4", 0, RoundHalfEven, "2"},
+		{"8", "4", 1, RoundDown, "1.5"},
+		{"100", "1.0000001", 5, RoundHalfUp, "46051704.16247"},
+		{"0.5", "3", 30, RoundHalfUp, "-0.630929753571457437099527114343"},
+		{"1", "3", 2, RoundHalfUp, "0.00"},
+	}
+	for _, a := range values {
+		var z Dec
+		x, base := parse(a.x), parse(a.base)
+		if _, err := z.Log(x, base, a.scale, a.mode); err != nil || z.String() != a.r {
+			t.Errorf("log %s base %s scale %d %s got %s %v want %s", a.x, a.base, a.scale, a.mode, z, err, a.r)
+		}
+	}
+	for _, a := range [][2]string{{"0", "2"}, {"2", "0"}, {"2", "-2"}, {"2", "1.0"}} {
+		var z Dec
+		if _, err := z.Log(parse(a[0]), parse(a[1]), 2, RoundHalfUp); err != ErrDomain {
+			t.Errorf("log %s base %s got %v want %v", a[0], a[1], err, ErrDomain)
+		}
+	}
+}
+
+func TestNullExp(t *testing.T) {
+	values := []struct {
+		x string
+		r string
+	}{
+		{"1", "2.72"},
+		{"", ""},
+	}
+	for _, a := range values {
+		var x, z NullDec
+		x.SetString(a.x)
+		if _, err := z.Exp(&x, 2, RoundHalfUp); err != nil || z.String() != a.r {
+			t.Errorf("exp %s got %s %v want %s", a.x, z, err, a.r)
+		}
+	}
+	var x, z NullDec
+	x.SetString("100")
+	if _, err := z.Exp(&x, 2, RoundHalfUp); err != ErrOverflow || !z.Null() {
+		t.Errorf("exp 100 got %s %v want null %v", z, err, ErrOverflow)
+	}
+}
+
+func TestNullLog(t *testing.T) {
+	values := []struct {
+		x, base string
+		r       string
+	}{
+		{"2", "10", "0.30"},
+		{"", "10", ""},
+		{"2", "", ""},
+	}
+	for _, a := range values {
+		var x, base, z NullDec
+		x.SetString(a.x)
+		base.SetString(a.base)
+		if _, err := z.Log(&x, &base, 2, RoundHalfUp); err != nil || z.String() != a.r {
+			t.Errorf("log %s base %s got %s %v want %s", a.x, a.base, z, err, a.r)
+		}
+	}
+	var x, z NullDec
+	x.SetString("2")
+	if _, err := z.Ln(&x, 2, RoundHalfUp); err != nil || z.String() != "0.69" {
+		t.Errorf("ln 2 got %s %v want 0.69", z, err)
+	}
+	if _, err := z.Log10(&x, 2, RoundHalfUp); err != nil || z.String() != "0.30" {
+		t.Errorf("log10 2 got %s %v want 0.30", z, err)
+	}
+	x.SetString("-2")
+	if _, err := z.Ln(&x, 2, RoundHalfUp); err != ErrDomain {
+		t.Errorf("ln -2 got %v want %v", err, ErrDomain)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import "github.com/dimdin/decimal"
+
+// Method is the repayment method of a loan.
+type Method uint8
+
+const (
+	Annuity Method = iota // equal installments of interest and principal
+	Linear                // equal repayments of principal
+	Bullet                // interest only, principal repaid in the last period
+)
+
+var methodNames = [...]string{
+	"annuity",
+	"linear",
+	"bullet",
+}
+
+// String returns the name of m.
+func (m Method) String() string {
+	if int(m) < len(methodNames) {
+		return methodNames[m]
+	}
+	return "unknown"
+}
+
+// Loan describes a loan repaid at the end of each period.
+type Loan struct {
+	Principal decimal.Dec          // amount borrowed
+	Rate      decimal.Dec          // interest rate per period
+	Periods   int                  // number of periods
+	Method    Method               // repayment method
+	Scale     uint8                // scale of the amounts of the schedule
+	Rounding  decimal.RoundingMode // rounding of the amounts of the schedule
+}
+
+// Row is one period of an amortization schedule.
+type Row struct {
+	Period    int         // period number starting from 1
+	Payment   decimal.Dec // interest plus principal paid
+	Interest  decimal.Dec // interest paid
+	Principal decimal.Dec // principal repaid
+	Balance   decimal.Dec // principal outstanding after the payment
+}
+
+// Schedule iterates over the rows of the amortization schedule of a loan.
+//
+// Every amount is rounded to the scale of the loan. The interest of each
+// period is the outstanding balance times the rate. The installment of an
+// annuity and the principal repayment of a linear loan are rounded once
+// and the last period repays the outstanding balance, so that the balance
+// ends at exactly zero and the principal repayments sum to the principal.
+type Schedule struct {
+	loan  Loan
+	k     *calc
+	fixed *decimal.Dec // installment of an annuity, repayment of a linear loan
+	row   Row
+}
+
+// NewSchedule returns the amortization schedule of loan.
+// decimal.ErrDomain is returned if the number of periods is not positive.
+func NewSchedule(loan *Loan) (*Schedule, error) {
+	if loan.Periods <= 0 {
+		return nil, decimal.ErrDomain
+	}
+	s := &Schedule{loan: *loan, k: newCalc()}
+	l := &s.loan
+	switch l.Method {
+	case Annuity:
+		var pv decimal.Dec
+		pv.Neg(&l.Principal)
+		pmt, err := PMT(&l.Rate, l.Periods, &pv, zero, End, l.Scale, l.Rounding)
+		if err != nil {
+			return nil, err
+		}
+		s.fixed = &pmt
+	case Linear:
+		s.fixed = s.k.round(s.k.div(&l.Principal, decimal.New(int64(l.Periods))), l.Scale, l.Rounding)
+	case Bullet:
+		s.fixed = s.k.round(zero, l.Scale, l.Rounding)
+	default:
+		return nil, decimal.ErrDomain
+	}
+	s.row.Balance = *s.k.round(&l.Principal, l.Scale, l.Rounding)
+	if s.k.err != nil {
+		return nil, s.k.err
+	}
+	return s, nil
+}
+
+// Next advances the schedule to the next row, which is then available
+// through Row. It returns false when the schedule is exhausted or an error
+// occurred.
+func (s *Schedule) Next() bool {
+	l := &s.loan
+	if s.row.Period >= l.Periods || s.k.err != nil {
+		return false
+	}
+	k := s.k
+	balance := &s.row.Balance
+	interest := k.round(k.mul(balance, &l.Rate), l.Scale, l.Rounding)
+	var principal *decimal.Dec
+	switch {
+	case s.row.Period+1 == l.Periods:
+		principal = balance
+	case l.Method == Annuity:
+		principal = k.sub(s.fixed, interest)
+	default:
+		principal = s.fixed
+	}
+	remaining := k.sub(balance, principal)
+	if remaining.Sign() != 0 && remaining.Sign() != balance.Sign() {
+		// never repay more than the outstanding balance
+		principal = balance
+		remaining = k.sub(balance, principal)
+	}
+	payment := k.add(interest, principal)
+	if k.err != nil {
+		return false
+	}
+	s.row = Row{
+		Period:    s.row.Period + 1,
+		Payment:   *payment,
+		Interest:  *interest,
+		Principal: *principal,
+		Balance:   *remaining,
+	}
+	return true
+}
+
+// Row returns the current row of the schedule.
+func (s *Schedule) Row() Row {
+	return s.row
+}
+
+// Err returns the first error that occurred while iterating.
+func (s *Schedule) Err() error {
+	return s.k.err
+}
+
+// Amortize returns all the rows of the amortization schedule of loan.
+func Amortize(loan *Loan) ([]Row, error) {
+	s, err := NewSchedule(loan)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]Row, 0, loan.Periods)
+	for s.Next() {
+		rows = append(rows, s.Row())
+	}
+	return rows, s.Err()
+}
@@ -0,0 +1,140 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dimdin/decimal"
+)
+
+func rowString(r Row) string {
+	return fmt.Sprint(r.Period, " ", r.Payment, " ", r.Interest, " ", r.Principal, " ", r.Balance)
+}
+
+func TestAmortize(t *testing.T) {
+	values := []struct {
+		principal, rate string
+		periods         int
+		method          Method
+		mode            decimal.RoundingMode
+		rows            []string
+	}{
+		{"1000.00", "0.01", 6, Annuity, decimal.RoundHalfUp, []string{
+			"1 172.55 10.00 162.55 837.45",
+			"2 172.55 8.37 164.18 673.27",
+			"3 172.55 6.73 165.82 507.45",
+			"4 172.55 5.07 167.48 339.97",
+			"5 172.55 3.40 169.15 170.82",
+			"6 172.53 1.71 170.82 0.00",
+		}},
+		{"1000.00", "0.01", 6, Linear, decimal.RoundHalfUp, []string{
+			"1 176.67 10.00 166.67 833.33",
+			"2 175.00 8.33 166.67 666.66",
+			"3 173.34 6.67 166.67 499.99",
+			"4 171.67 5.00 166.67 333.32",
+			"5 170.00 3.33 166.67 166.65",
+			"6 168.32 1.67 166.65 0.00",
+		}},
+		{"1000", "0.01", 3, Bullet, decimal.RoundHalfUp, []string{
+			"1 10.00 10.00 0.00 1000.00",
+			"2 10.00 10.00 0.00 1000.00",
+			"3 1010.00 10.00 1000.00 0.00",
+		}},
+		{"100", "0", 3, Annuity, decimal.RoundCeiling, []string{
+			"1 33.34 0.00 33.34 66.66",
+			"2 33.34 0.00 33.34 33.32",
+			"3 33.32 0.00 33.32 0.00",
+		}},
+		{"100", "0", 3, Annuity, decimal.RoundDown, []string{
+			"1 33.33 0.00 33.33 66.67",
+			"2 33.33 0.00 33.33 33.34",
+			"3 33.34 0.00 33.34 0.00",
+		}},
+		// the rounded up installment repays the loan early
+		{"0.10", "0", 4, Linear, decimal.RoundUp, []string{
+			"1 0.03 0.00 0.03 0.07",
+			"2 0.03 0.00 0.03 0.04",
+			"3 0.03 0.00 0.03 0.01",
+			"4 0.01 0.00 0.01 0.00",
+		}},
+		{"0.03", "0", 4, Linear, decimal.RoundUp, []string{
+			"1 0.01 0.00 0.01 0.02",
+			"2 0.01 0.00 0.01 0.01",
+			"3 0.01 0.00 0.01 0.00",
+			"4 0.00 0.00 0.00 0.00",
+		}},
+	}
+	for _, a := range values {
+		loan := Loan{
+			Principal: *dec(a.principal),
+			Rate:      *dec(a.rate),
+			Periods:   a.periods,
+			Method:    a.method,
+			Scale:     2,
+			Rounding:  a.mode,
+		}
+		rows, err := Amortize(&loan)
+		if err != nil || len(rows) != len(a.rows) {
+			t.Errorf("%s %s %s got %d rows %v want %v", a.method, a.principal, a.rate, len(rows), err, a.rows)
+			continue
+		}
+		var sum decimal.Dec
+		for i, r := range rows {
+			if rowString(r) != a.rows[i] {
+				t.Errorf("%s %s %s row %d got %s want %s", a.method, a.principal, a.rate, i+1, rowString(r), a.rows[i])
+			}
+			sum.Add(&sum, &r.Principal)
+		}
+		if sum.Cmp(&loan.Principal) != 0 {
+			t.Errorf("%s %s %s repaid %s", a.method, a.principal, a.rate, sum)
+		}
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	loan := Loan{
+		Principal: *dec("200000"),
+		Rate:      *monthly("0.05"),
+		Periods:   360,
+		Method:    Annuity,
+		Scale:     2,
+		Rounding:  decimal.RoundHalfUp,
+	}
+	s, err := NewSchedule(&loan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	var interest decimal.Dec
+	var last Row
+	for s.Next() {
+		n++
+		last = s.Row()
+		interest.Add(&interest, &last.Interest)
+		if n < 360 && last.Payment.String() != "1073.64" {
+			t.Errorf("row %s", rowString(last))
+		}
+	}
+	if s.Err() != nil || n != 360 || last.Balance.Sign() != 0 {
+		t.Errorf("got %d rows %s %v", n, rowString(last), s.Err())
+	}
+	if rowString(last) != "360 1076.48 4.47 1072.01 0.00" || interest.String() != "186513.24" {
+		t.Errorf("last row %s interest %s", rowString(last), interest)
+	}
+	if s.Next() {
+		t.Error("next after the last row")
+	}
+
+	for _, l := range []Loan{{Periods: 0}, {Periods: 1, Method: Method(9)}} {
+		if _, err := Amortize(&l); err != decimal.ErrDomain {
+			t.Errorf("%s %d got %v want %v", l.Method, l.Periods, err, decimal.ErrDomain)
+		}
+	}
+	if Method(9).String() != "unknown" || Bullet.String() != "bullet" {
+		t.Error("method names")
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import (
+	"time"
+
+	"github.com/dimdin/decimal"
+)
+
+// Frequency is the number of coupon payments per year.
+type Frequency uint8
+
+const (
+	Annual     Frequency = 1
+	SemiAnnual Frequency = 2
+	Quarterly  Frequency = 4
+)
+
+var hundred = decimal.New(100)
+
+// Bond is a fixed rate bond with prices quoted per 100 of face value.
+//
+// The coupon dates are found by stepping back from the maturity by
+// 12/Frequency months; a maturity at the end of a month has its coupons at
+// the end of the month. Between coupon dates the price is discounted by
+// the fraction of the coupon period counted according to Basis. A bond
+// in its last coupon period is priced with simple interest.
+type Bond struct {
+	Settlement time.Time   // date the bond is bought
+	Maturity   time.Time   // date the bond is redeemed
+	Coupon     decimal.Dec // annual coupon rate
+	Redemption decimal.Dec // redemption value per 100 of face value
+	Frequency  Frequency   // coupons per year
+	Basis      DayCount    // day count convention
+}
+
+// lastOfMonth reports whether t is the last day of its month.
+func lastOfMonth(t time.Time) bool {
+	return t.AddDate(0, 0, 1).Day() == 1
+}
+
+// addMonths returns t moved by n months. The day is kept within the month,
+// and the last day of a month is moved to the last day of the month.
+func addMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	if d > last || lastOfMonth(t) {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+// period is the coupon period of the settlement of a bond.
+type period struct {
+	e   *decimal.Dec // days of the coupon period
+	a   *decimal.Dec // days from the start of the period to the settlement
+	dsc *decimal.Dec // days from the settlement to the next coupon
+	n   int          // coupons remaining
+}
+
+// period returns the coupon period of the settlement of b.
+// decimal.ErrDomain is returned for an invalid bond.
+func (b *Bond) period(k *calc) (*period, error) {
+	settle, maturity := date(b.Settlement), date(b.Maturity)
+	f := int(b.Frequency)
+	if f == 0 || 12%f != 0 || !settle.Before(maturity) || int(b.Basis) >= len(dayCountNames) {
+		return nil, decimal.ErrDomain
+	}
+	months := 12 / f
+	n := 1
+	next := maturity
+	prev := addMonths(maturity, -months)
+	for prev.After(settle) {
+		n++
+		next = prev
+		prev = addMonths(maturity, -months*n)
+	}
+	p := &period{n: n, a: decimal.New(int64(b.Basis.Days(prev, settle)))}
+	switch b.Basis {
+	case Actual365Fixed:
+		p.e = k.div(decimal.New(365), decimal.New(int64(f)))
+	case ActualActualISDA:
+		p.e = decimal.New(int64(actual(prev, next)))
+	default:
+		p.e = k.div(decimal.New(360), decimal.New(int64(f)))
+	}
+	if b.Basis == Thirty360US || b.Basis == Thirty360E {
+		p.dsc = k.sub(p.e, p.a)
+	} else {
+		p.dsc = decimal.New(int64(actual(settle, next)))
+	}
+	return p, nil
+}
+
+// coupon returns the coupon payment per 100 of face value.
+func (b *Bond) coupon(k *calc) *decimal.Dec {
+	return k.div(k.mul(&b.Coupon, hundred), decimal.New(int64(b.Frequency)))
+}
+
+// accrued returns the accrued interest per 100 of face value.
+func (b *Bond) accrued(k *calc, p *period) *decimal.Dec {
+	return k.div(k.mul(b.coupon(k), p.a), p.e)
+}
+
+// discount returns 1/(1 + y/f) or decimal.ErrDomain if it is not positive.
+func (b *Bond) discount(k *calc, y *decimal.Dec) (*decimal.Dec, error) {
+	r := k.add(one, k.div(y, decimal.New(int64(b.Frequency))))
+	if k.err == nil && r.Sign() <= 0 {
+		return nil, decimal.ErrDomain
+	}
+	return k.div(one, r), nil
+}
+
+// sums returns the sums over the cash flows cf paid in e coupon periods of
+// cf*v**e, e*cf*v**e and e*(e+1)*cf*v**e, the present value and its first
+// and second moments.
+func (b *Bond) sums(k *calc, p *period, v *decimal.Dec) (pv, w, x *decimal.Dec) {
+	c := b.coupon(k)
+	t := k.div(p.dsc, p.e)
+	vt := k.powDec(v, t)
+	pv, w, x = new(decimal.Dec), new(decimal.Dec), new(decimal.Dec)
+	for i := 0; i < p.n; i++ {
+		cf := c
+		if i == p.n-1 {
+			cf = k.add(c, &b.Redemption)
+		}
+		e := k.add(decimal.New(int64(i)), t)
+		a := k.mul(cf, vt)
+		pv = k.add(pv, a)
+		w = k.add(w, k.mul(e, a))
+		x = k.add(x, k.mul(k.mul(e, k.add(e, one)), a))
+		vt = k.mul(vt, v)
+	}
+	return pv, w, x
+}
+
+// dirty returns the price of b including accrued interest at yield y.
+func (b *Bond) dirty(k *calc, p *period, y *decimal.Dec) (*decimal.Dec, error) {
+	if p.n == 1 {
+		// (redemption + coupon) / (1 + dsc/e * y/f)
+		r := k.add(one, k.div(k.mul(p.dsc, y), k.mul(p.e, decimal.New(int64(b.Frequency)))))
+		if k.err == nil && r.Sign() <= 0 {
+			return nil, decimal.ErrDomain
+		}
+		return k.div(k.add(&b.Redemption, b.coupon(k)), r), nil
+	}
+	v, err := b.discount(k, y)
+	if err != nil {
+		return nil, err
+	}
+	pv, _, _ := b.sums(k, p, v)
+	return pv, nil
+}
+
+// AccruedInterest returns the interest per 100 of face value accrued from
+// the last coupon date to the settlement.
+func (b *Bond) AccruedInterest(scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k := newCalc()
+	p, err := b.period(k)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	return k.result(b.accrued(k, p), scale, mode)
+}
+
+// DirtyPrice returns the price per 100 of face value including accrued
+// interest at the annual yield y.
+func (b *Bond) DirtyPrice(y *decimal.Dec, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k := newCalc()
+	p, err := b.period(k)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	v, err := b.dirty(k, p, y)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	return k.result(v, scale, mode)
+}
+
+// CleanPrice returns the price per 100 of face value excluding accrued
+// interest at the annual yield y.
+func (b *Bond) CleanPrice(y *decimal.Dec, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k := newCalc()
+	p, err := b.period(k)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	v, err := b.dirty(k, p, y)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	return k.result(k.sub(v, b.accrued(k, p)), scale, mode)
+}
+
+// Yield returns the annual yield to maturity of b at the given clean price.
+// The yield is found by Newton's method starting from the coupon rate as by
+// RATE. ErrNoConvergence is returned if no yield is found.
+func (b *Bond) Yield(price *decimal.Dec, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k := newCalc()
+	p, err := b.period(k)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	f := decimal.New(int64(b.Frequency))
+	dirty := k.add(price, b.accrued(k, p))
+	if p.n == 1 {
+		// ((redemption + coupon) / dirty - 1) * f * e / dsc
+		y := k.sub(k.div(k.add(&b.Redemption, b.coupon(k)), dirty), one)
+		y = k.div(k.mul(y, k.mul(f, p.e)), p.dsc)
+		return k.result(y, scale, mode)
+	}
+	y := new(decimal.Dec).Set(&b.Coupon)
+	for i := 0; i < maxIterations && k.err == nil; i++ {
+		// f(y) = pv - dirty, f'(y) = -w*v/f
+		v, err := b.discount(k, y)
+		if err != nil {
+			break
+		}
+		pv, w, _ := b.sums(k, p, v)
+		df := k.sub(zero, k.div(k.mul(w, v), f))
+		if k.err != nil || df.Sign() == 0 {
+			break
+		}
+		step := k.div(k.sub(pv, dirty), df)
+		y = k.sub(y, step)
+		if k.err == nil && converged(step, scale) {
+			return k.result(y, scale, mode)
+		}
+	}
+	if k.err != nil {
+		return decimal.Dec{}, k.err
+	}
+	return decimal.Dec{}, ErrNoConvergence
+}
+
+// durations returns the Macaulay duration in years, the modified duration
+// and the convexity of b at the annual yield y.
+func (b *Bond) durations(y *decimal.Dec) (k *calc, mac, mod, conv *decimal.Dec, err error) {
+	k = newCalc()
+	p, err := b.period(k)
+	if err != nil {
+		return k, nil, nil, nil, err
+	}
+	v, err := b.discount(k, y)
+	if err != nil {
+		return k, nil, nil, nil, err
+	}
+	f := decimal.New(int64(b.Frequency))
+	pv, w, x := b.sums(k, p, v)
+	// mac = w/pv/f, mod = mac*v, conv = x*v**2/pv/f**2
+	mac = k.div(w, k.mul(pv, f))
+	mod = k.mul(mac, v)
+	conv = k.div(k.mul(x, k.mul(v, v)), k.mul(pv, k.mul(f, f)))
+	return k, mac, mod, conv, nil
+}
+
+// MacaulayDuration returns the Macaulay duration in years of b at the
+// annual yield y, the average time of its cash flows weighted by their
+// present values.
+func (b *Bond) MacaulayDuration(y *decimal.Dec, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k, mac, _, _, err := b.durations(y)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	return k.result(mac, scale, mode)
+}
+
+// ModifiedDuration returns the modified duration of b at the annual
+// yield y, the Macaulay duration divided by 1 + y/Frequency.
+func (b *Bond) ModifiedDuration(y *decimal.Dec, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k, _, mod, _, err := b.durations(y)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	return k.result(mod, scale, mode)
+}
+
+// Convexity returns the convexity of b at the annual yield y.
+func (b *Bond) Convexity(y *decimal.Dec, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k, _, _, conv, err := b.durations(y)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	return k.result(conv, scale, mode)
+}
@@ -0,0 +1,152 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import (
+	"testing"
+
+	"github.com/dimdin/decimal"
+)
+
+func bond(settlement, maturity, coupon string, f Frequency, basis DayCount) *Bond {
+	return &Bond{
+		Settlement: day(settlement),
+		Maturity:   day(maturity),
+		Coupon:     *dec(coupon),
+		Redemption: *decimal.New(100),
+		Frequency:  f,
+		Basis:      basis,
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	values := []struct {
+		t string
+		n int
+		r string
+	}{
+		{"2017-11-15", -6, "2017-05-15"},
+		{"2017-08-31", -6, "2017-02-28"},
+		{"2016-08-31", -6, "2016-02-29"},
+		{"2016-02-29", 6, "2016-08-31"},
+		{"2016-05-30", -3, "2016-02-29"},
+		{"2016-04-30", -2, "2016-02-29"},
+		{"2016-03-30", 12, "2017-03-30"},
+	}
+	for _, a := range values {
+		if r := addMonths(day(a.t), a.n).Format("2006-01-02"); r != a.r {
+			t.Errorf("%s %+d got %s want %s", a.t, a.n, r, a.r)
+		}
+	}
+}
+
+func TestBondPrice(t *testing.T) {
+	values := []struct {
+		b                 *Bond
+		y                 string
+		clean, dirty, acc string
+	}{
+		{bond("2008-02-15", "2017-11-15", "0.0575", SemiAnnual, Thirty360US), "0.065", "94.63436162", "96.07186162", "1.43750000"},
+		{bond("2014-03-10", "2020-06-30", "0.04", Annual, ActualActualISDA), "0.035", "102.77164184", "105.54424458", "2.77260274"},
+		{bond("2014-03-10", "2020-06-30", "0.04", Quarterly, ActualActualISDA), "0.035", "102.81844597", "103.58511264", "0.76666667"},
+		{bond("2014-03-10", "2014-06-30", "0.04", SemiAnnual, Thirty360US), "0.035", "100.14293131", "100.92070908", "0.77777778"},
+	}
+	for _, a := range values {
+		y := dec(a.y)
+		clean, err := a.b.CleanPrice(y, 8, decimal.RoundHalfUp)
+		if err != nil || clean.String() != a.clean {
+			t.Errorf("%v clean got %s %v want %s", a.b, clean, err, a.clean)
+		}
+		dirty, err := a.b.DirtyPrice(y, 8, decimal.RoundHalfUp)
+		if err != nil || dirty.String() != a.dirty {
+			t.Errorf("%v dirty got %s %v want %s", a.b, dirty, err, a.dirty)
+		}
+		acc, err := a.b.AccruedInterest(8, decimal.RoundHalfUp)
+		if err != nil || acc.String() != a.acc {
+			t.Errorf("%v accrued got %s %v want %s", a.b, acc, err, a.acc)
+		}
+	}
+}
+
+func TestBondYield(t *testing.T) {
+	values := []struct {
+		b     *Bond
+		price string
+		scale uint8
+		y     string
+	}{
+		{bond("2008-02-15", "2016-11-15", "0.0575", SemiAnnual, Thirty360US), "95.04287", 8, "0.06500001"},
+		{bond("2014-03-10", "2020-06-30", "0.04", Quarterly, ActualActualISDA), "101.25", 10, "0.0377623184"},
+		{bond("2014-03-10", "2014-06-30", "0.04", SemiAnnual, Thirty360US), "100.5", 10, "0.0233381539"},
+		{bond("2008-02-15", "2017-11-15", "0.0575", SemiAnnual, Thirty360US), "94.63436162", 8, "0.06500000"},
+	}
+	for _, a := range values {
+		y, err := a.b.Yield(dec(a.price), a.scale, decimal.RoundHalfUp)
+		if err != nil || y.String() != a.y {
+			t.Errorf("%v yield got %s %v want %s", a.b, y, err, a.y)
+		}
+	}
+}
+
+func TestBondDuration(t *testing.T) {
+	values := []struct {
+		b             *Bond
+		y             string
+		mac, mod, cvx string
+	}{
+		{bond("2018-07-01", "2048-01-01", "0.08", SemiAnnual, ActualActualISDA), "0.09", "10.91914528", "10.44894285", "187.58527571"},
+		{bond("2008-01-01", "2016-01-01", "0.08", SemiAnnual, ActualActualISDA), "0.09", "5.99377496", "5.73566981", "41.95760284"},
+		{bond("2014-03-10", "2020-06-30", "0.04", Annual, ActualActualISDA), "0.035", "5.56224589", "5.37415062", "36.62075104"},
+		{bond("2014-03-10", "2020-06-30", "0.04", Quarterly, ActualActualISDA), "0.035", "5.57725789", "5.52888019", "34.52239221"},
+	}
+	for _, a := range values {
+		y := dec(a.y)
+		mac, err := a.b.MacaulayDuration(y, 8, decimal.RoundHalfUp)
+		if err != nil || mac.String() != a.mac {
+			t.Errorf("%v macaulay got %s %v want %s", a.b, mac, err, a.mac)
+		}
+		mod, err := a.b.ModifiedDuration(y, 8, decimal.RoundHalfUp)
+		if err != nil || mod.String() != a.mod {
+			t.Errorf("%v modified got %s %v want %s", a.b, mod, err, a.mod)
+		}
+		cvx, err := a.b.Convexity(y, 8, decimal.RoundHalfUp)
+		if err != nil || cvx.String() != a.cvx {
+			t.Errorf("%v convexity got %s %v want %s", a.b, cvx, err, a.cvx)
+		}
+	}
+}
+
+func TestBondDomain(t *testing.T) {
+	bonds := []*Bond{
+		bond("2017-11-15", "2017-11-15", "0.05", Annual, Thirty360US),
+		bond("2018-01-01", "2017-11-15", "0.05", Annual, Thirty360US),
+		bond("2014-01-01", "2017-11-15", "0.05", 0, Thirty360US),
+		bond("2014-01-01", "2017-11-15", "0.05", 5, Thirty360US),
+		bond("2014-01-01", "2017-11-15", "0.05", Annual, DayCount(9)),
+	}
+	for _, b := range bonds {
+		if _, err := b.CleanPrice(dec("0.05"), 2, decimal.RoundHalfUp); err != decimal.ErrDomain {
+			t.Errorf("%v got %v want %v", b, err, decimal.ErrDomain)
+		}
+		if _, err := b.Yield(dec("100"), 2, decimal.RoundHalfUp); err != decimal.ErrDomain {
+			t.Errorf("%v yield got %v want %v", b, err, decimal.ErrDomain)
+		}
+		if _, err := b.MacaulayDuration(dec("0.05"), 2, decimal.RoundHalfUp); err != decimal.ErrDomain {
+			t.Errorf("%v duration got %v want %v", b, err, decimal.ErrDomain)
+		}
+	}
+	b := bond("2014-01-01", "2017-11-15", "0.05", SemiAnnual, Thirty360US)
+	if _, err := b.DirtyPrice(dec("-2"), 2, decimal.RoundHalfUp); err != decimal.ErrDomain {
+		t.Errorf("yield -2 got %v want %v", err, decimal.ErrDomain)
+	}
+	if _, err := b.Convexity(dec("-3"), 2, decimal.RoundHalfUp); err != decimal.ErrDomain {
+		t.Errorf("yield -3 got %v want %v", err, decimal.ErrDomain)
+	}
+	// monthly coupons
+	b.Frequency = 12
+	if p, err := b.CleanPrice(dec("0.05"), 2, decimal.RoundHalfUp); err != nil || p.String() != "100.00" {
+		t.Errorf("monthly got %s %v", p, err)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import (
+	"time"
+
+	"github.com/dimdin/decimal"
+)
+
+// DayCount is a day count convention, the way the fraction of a year
+// between two dates is counted when accruing interest.
+type DayCount uint8
+
+const (
+	Thirty360US      DayCount = iota // 30/360 US, the bond basis
+	Thirty360E                       // 30E/360, the eurobond basis
+	Actual360                        // ACT/360
+	Actual365Fixed                   // ACT/365F
+	ActualActualISDA                 // ACT/ACT ISDA
+)
+
+var dayCountNames = [...]string{
+	"30/360 US",
+	"30E/360",
+	"ACT/360",
+	"ACT/365F",
+	"ACT/ACT ISDA",
+}
+
+// String returns the name of c.
+func (c DayCount) String() string {
+	if int(c) < len(dayCountNames) {
+		return dayCountNames[c]
+	}
+	return "unknown"
+}
+
+// date returns the calendar date of t at midnight UTC.
+func date(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// actual returns the number of calendar days from from to to.
+func actual(from, to time.Time) int {
+	return int(date(to).Sub(date(from)) / (24 * time.Hour))
+}
+
+// lastOfFebruary reports whether t is the last day of February.
+func lastOfFebruary(t time.Time) bool {
+	return t.Month() == time.February && t.AddDate(0, 0, 1).Month() == time.March
+}
+
+// daysInYear returns the number of days of year y.
+func daysInYear(y int) int {
+	return actual(time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(y+1, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// Days returns the number of days from from to to counted according to c.
+// The dates are taken in their own location and the time of day is
+// ignored. Days is negative if to is before from.
+func (c DayCount) Days(from, to time.Time) int {
+	switch c {
+	case Thirty360US, Thirty360E:
+		from, to = date(from), date(to)
+		y1, m1, d1 := from.Date()
+		y2, m2, d2 := to.Date()
+		if c == Thirty360US {
+			if lastOfFebruary(from) {
+				if lastOfFebruary(to) {
+					d2 = 30
+				}
+				d1 = 30
+			}
+			if d2 == 31 && d1 >= 30 {
+				d2 = 30
+			}
+		} else if d2 == 31 {
+			d2 = 30
+		}
+		if d1 == 31 {
+			d1 = 30
+		}
+		return 360*(y2-y1) + 30*(int(m2)-int(m1)) + d2 - d1
+	}
+	return actual(from, to)
+}
+
+// fraction returns the fraction of a year from from to to as the exact
+// ratio num/den. decimal.ErrDomain is returned for an unknown convention.
+func (c DayCount) fraction(from, to time.Time) (num, den int64, err error) {
+	switch c {
+	case Thirty360US, Thirty360E, Actual360:
+		return int64(c.Days(from, to)), 360, nil
+	case Actual365Fixed:
+		return int64(c.Days(from, to)), 365, nil
+	case ActualActualISDA:
+		if date(to).Before(date(from)) {
+			num, den, err = c.fraction(to, from)
+			return -num, den, err
+		}
+		// the days in each calendar year over the days of that year,
+		// d365/365 + d366/366 over the common denominator 365*366
+		from, to = date(from), date(to)
+		var d365, d366 int64
+		for y := from.Year(); y <= to.Year(); y++ {
+			start := time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+			end := time.Date(y+1, 1, 1, 0, 0, 0, 0, time.UTC)
+			if y == from.Year() {
+				start = from
+			}
+			if y == to.Year() {
+				end = to
+			}
+			if daysInYear(y) == 366 {
+				d366 += int64(actual(start, end))
+			} else {
+				d365 += int64(actual(start, end))
+			}
+		}
+		return 366*d365 + 365*d366, 365 * 366, nil
+	}
+	return 0, 0, decimal.ErrDomain
+}
+
+// YearFraction returns the fraction of a year from from to to counted
+// according to c, rounded to the given scale according to mode.
+// decimal.ErrDomain is returned for an unknown convention.
+func (c DayCount) YearFraction(from, to time.Time, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	var z decimal.Dec
+	num, den, err := c.fraction(from, to)
+	if err != nil {
+		return z, err
+	}
+	_, err = z.DivModeChecked(decimal.New(num), decimal.New(den), scale, mode)
+	return z, err
+}
+
+// Accrue returns the simple interest of principal at the annual rate from
+// from to to, with the fraction of the year counted according to
+// convention. The interest is rounded half up to the given scale.
+func Accrue(principal, rate *decimal.Dec, from, to time.Time, convention DayCount, scale uint8) (decimal.Dec, error) {
+	var z decimal.Dec
+	num, den, err := convention.fraction(from, to)
+	if err != nil {
+		return z, err
+	}
+	// principal*rate*num / den rounded once
+	k := newCalc()
+	v := k.mul(k.mul(principal, rate), decimal.New(num))
+	if k.err != nil {
+		return z, k.err
+	}
+	_, err = z.DivModeChecked(v, decimal.New(den), scale, decimal.RoundHalfUp)
+	return z, err
+}
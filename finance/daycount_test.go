@@ -0,0 +1,126 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dimdin/decimal"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestDays(t *testing.T) {
+	values := []struct {
+		from, to string
+		us, e    int
+		act      int
+	}{
+		{"2007-01-15", "2007-07-15", 180, 180, 181},
+		{"2007-02-28", "2007-08-31", 180, 182, 184},
+		{"2007-01-31", "2007-02-28", 28, 28, 28},
+		{"2008-02-29", "2009-02-28", 360, 359, 365},
+		{"2007-01-31", "2007-03-31", 60, 60, 59},
+		{"2007-01-15", "2007-03-31", 76, 75, 75},
+		{"2007-07-15", "2007-01-15", -180, -180, -181},
+	}
+	for _, a := range values {
+		from, to := day(a.from), day(a.to)
+		if d := Thirty360US.Days(from, to); d != a.us {
+			t.Errorf("%s %s %s got %d want %d", Thirty360US, a.from, a.to, d, a.us)
+		}
+		if d := Thirty360E.Days(from, to); d != a.e {
+			t.Errorf("%s %s %s got %d want %d", Thirty360E, a.from, a.to, d, a.e)
+		}
+		for _, c := range []DayCount{Actual360, Actual365Fixed, ActualActualISDA} {
+			if d := c.Days(from, to); d != a.act {
+				t.Errorf("%s %s %s got %d want %d", c, a.from, a.to, d, a.act)
+			}
+		}
+	}
+	// the time of day and the location are ignored
+	from := time.Date(2014, 3, 1, 23, 59, 0, 0, time.FixedZone("EET", 2*3600))
+	to := time.Date(2014, 3, 2, 0, 1, 0, 0, time.UTC)
+	if d := Actual360.Days(from, to); d != 1 {
+		t.Errorf("got %d want 1", d)
+	}
+}
+
+func TestYearFraction(t *testing.T) {
+	values := []struct {
+		c        DayCount
+		from, to string
+		r        string
+	}{
+		{Thirty360US, "2007-02-28", "2007-08-31", "0.5000000000"},
+		{Thirty360E, "2007-02-28", "2007-08-31", "0.5055555556"},
+		{Actual360, "2003-11-01", "2004-05-01", "0.5055555556"},
+		{Actual365Fixed, "2003-11-01", "2004-05-01", "0.4986301370"},
+		{ActualActualISDA, "2003-11-01", "2004-05-01", "0.4977243806"},
+		{ActualActualISDA, "2007-12-28", "2008-02-29", "0.1721610899"},
+		{ActualActualISDA, "1999-02-01", "2001-07-01", "2.4109589041"},
+		{ActualActualISDA, "2004-05-01", "2003-11-01", "-0.4977243806"},
+		{ActualActualISDA, "2004-01-01", "2005-01-01", "1.0000000000"},
+	}
+	for _, a := range values {
+		r, err := a.c.YearFraction(day(a.from), day(a.to), 10, decimal.RoundHalfUp)
+		if err != nil || r.String() != a.r {
+			t.Errorf("%s %s %s got %s %v want %s", a.c, a.from, a.to, r, err, a.r)
+		}
+	}
+	if _, err := DayCount(9).YearFraction(day("2014-01-01"), day("2015-01-01"), 2, decimal.RoundHalfUp); err != decimal.ErrDomain {
+		t.Errorf("got %v want %v", err, decimal.ErrDomain)
+	}
+	if DayCount(9).String() != "unknown" {
+		t.Error("day count name")
+	}
+}
+
+func TestAccrue(t *testing.T) {
+	values := []struct {
+		c DayCount
+		r string
+	}{
+		{Thirty360US, "25000.00"},
+		{Actual360, "25277.78"},
+		{Actual365Fixed, "24931.51"},
+		{ActualActualISDA, "24886.22"},
+	}
+	for _, a := range values {
+		r, err := Accrue(dec("1000000"), dec("0.05"), day("2003-11-01"), day("2004-05-01"), a.c, 2)
+		if err != nil || r.String() != a.r {
+			t.Errorf("%s got %s %v want %s", a.c, r, err, a.r)
+		}
+	}
+	// exact ties are rounded once
+	ties := []struct {
+		principal string
+		from, to  string
+		c         DayCount
+		r         string
+	}{
+		{"0.365", "2024-01-01", "2024-01-06", Actual365Fixed, "0.01"},
+		{"0.0375", "2024-01-01", "2024-02-18", Actual360, "0.01"},
+		{"0.0375", "2024-02-18", "2024-01-01", Actual360, "-0.01"},
+		{"0.366", "2024-01-01", "2024-01-06", ActualActualISDA, "0.01"},
+		{"7.31", "2023-12-31", "2024-01-01", ActualActualISDA, "0.02"},
+	}
+	for _, a := range ties {
+		r, err := Accrue(dec(a.principal), dec("1"), day(a.from), day(a.to), a.c, 2)
+		if err != nil || r.String() != a.r {
+			t.Errorf("%s %s %s got %s %v want %s", a.principal, a.from, a.to, r, err, a.r)
+		}
+	}
+	if _, err := Accrue(dec("1"), dec("1"), day("2024-01-01"), day("2024-01-06"), DayCount(9), 2); err != decimal.ErrDomain {
+		t.Errorf("got %v want %v", err, decimal.ErrDomain)
+	}
+}
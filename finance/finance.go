@@ -0,0 +1,155 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package finance implements time value of money functions on decimal.Dec.
+//
+// Intermediate results are computed with decimal.MaxPrecision significant
+// digits and the final result is rounded once to the requested scale
+// according to the requested rounding mode. The functions follow the sign
+// convention of the spreadsheet functions with the same names: money paid
+// out is negative and money received is positive.
+package finance
+
+import (
+	"errors"
+
+	"github.com/dimdin/decimal"
+)
+
+// ErrNoConvergence is returned when an iterative method does not converge.
+var ErrNoConvergence = errors.New("Iteration did not converge")
+
+// maxIterations is the iteration limit of the iterative methods.
+const maxIterations = 100
+
+// When determines whether payments are due at the end or at the beginning
+// of each period.
+type When uint8
+
+const (
+	End   When = iota // payments at the end of each period
+	Begin             // payments at the beginning of each period
+)
+
+var (
+	zero       = decimal.New(0)
+	one        = decimal.New(1)
+	tenPercent = new(decimal.Dec).Div(one, decimal.New(10), 1)
+)
+
+// calc evaluates expressions with decimal.MaxPrecision significant digits.
+// The first error is kept and makes the following operations no-ops.
+type calc struct {
+	c   *decimal.Context
+	err error
+}
+
+func newCalc() *calc {
+	return &calc{c: decimal.NewContext(decimal.MaxPrecision, decimal.RoundHalfEven)}
+}
+
+// check records the first error as one of the decimal package errors.
+func (k *calc) check(err error) {
+	if err == nil || k.err != nil {
+		return
+	}
+	for _, e := range []error{decimal.ErrOverflow, decimal.ErrDivisionByZero, decimal.ErrDomain} {
+		if errors.Is(err, e) {
+			err = e
+			break
+		}
+	}
+	k.err = err
+}
+
+func (k *calc) add(x, y *decimal.Dec) *decimal.Dec {
+	z := new(decimal.Dec)
+	if k.err == nil {
+		_, err := k.c.Add(z, x, y)
+		k.check(err)
+	}
+	return z
+}
+
+func (k *calc) sub(x, y *decimal.Dec) *decimal.Dec {
+	z := new(decimal.Dec)
+	if k.err == nil {
+		_, err := k.c.Sub(z, x, y)
+		k.check(err)
+	}
+	return z
+}
+
+func (k *calc) mul(x, y *decimal.Dec) *decimal.Dec {
+	z := new(decimal.Dec)
+	if k.err == nil {
+		_, err := k.c.Mul(z, x, y)
+		k.check(err)
+	}
+	return z
+}
+
+func (k *calc) div(x, y *decimal.Dec) *decimal.Dec {
+	z := new(decimal.Dec)
+	if k.err == nil {
+		_, err := k.c.Div(z, x, y)
+		k.check(err)
+	}
+	return z
+}
+
+// pow returns x**n by repeated squaring.
+func (k *calc) pow(x *decimal.Dec, n int) *decimal.Dec {
+	if n < 0 {
+		return k.div(one, k.pow(x, -n))
+	}
+	z := new(decimal.Dec).Set(one)
+	s := new(decimal.Dec).Set(x)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			z = k.mul(z, s)
+		}
+		if n > 1 {
+			s = k.mul(s, s)
+		}
+	}
+	return z
+}
+
+// ln returns the natural logarithm of x.
+func (k *calc) ln(x *decimal.Dec) *decimal.Dec {
+	z := new(decimal.Dec)
+	if k.err == nil {
+		_, err := z.Ln(x, 34, decimal.RoundHalfEven)
+		k.check(err)
+	}
+	return z
+}
+
+// powDec returns x**y.
+func (k *calc) powDec(x, y *decimal.Dec) *decimal.Dec {
+	z := new(decimal.Dec)
+	if k.err == nil {
+		_, err := z.PowDecMode(x, y, 36, decimal.RoundHalfEven)
+		k.check(err)
+	}
+	return z
+}
+
+// round returns x rounded to the given scale according to mode.
+func (k *calc) round(x *decimal.Dec, scale uint8, mode decimal.RoundingMode) *decimal.Dec {
+	z := new(decimal.Dec)
+	if k.err == nil {
+		_, err := z.QuantizeChecked(x, scale, mode)
+		k.check(err)
+	}
+	return z
+}
+
+// result returns x rounded to the given scale according to mode,
+// or the first error.
+func (k *calc) result(x *decimal.Dec, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	z := k.round(x, scale, mode)
+	return *z, k.err
+}
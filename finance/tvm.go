@@ -0,0 +1,250 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import "github.com/dimdin/decimal"
+
+// annuity returns (1+rate)**nper and the annuity factor
+// (1+rate*when) * ((1+rate)**nper - 1) / rate for a non zero rate.
+func (k *calc) annuity(rate *decimal.Dec, nper int, when When) (g, a *decimal.Dec) {
+	g = k.pow(k.add(one, rate), nper)
+	a = k.div(k.sub(g, one), rate)
+	if when == Begin {
+		a = k.mul(a, k.add(one, rate))
+	}
+	return g, a
+}
+
+// PV returns the present value of an investment with nper periodic
+// payments pmt and a future value fv at the given rate per period.
+func PV(rate *decimal.Dec, nper int, pmt, fv *decimal.Dec, when When, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k := newCalc()
+	n := decimal.New(int64(nper))
+	var v *decimal.Dec
+	if rate.Sign() == 0 {
+		// -(fv + pmt*nper)
+		v = k.sub(zero, k.add(fv, k.mul(pmt, n)))
+	} else {
+		// -(fv + pmt*a) / g
+		g, a := k.annuity(rate, nper, when)
+		v = k.div(k.sub(zero, k.add(fv, k.mul(pmt, a))), g)
+	}
+	return k.result(v, scale, mode)
+}
+
+// FV returns the future value of an investment with a present value pv
+// and nper periodic payments pmt at the given rate per period.
+func FV(rate *decimal.Dec, nper int, pmt, pv *decimal.Dec, when When, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k := newCalc()
+	n := decimal.New(int64(nper))
+	var v *decimal.Dec
+	if rate.Sign() == 0 {
+		// -(pv + pmt*nper)
+		v = k.sub(zero, k.add(pv, k.mul(pmt, n)))
+	} else {
+		// -(pv*g + pmt*a)
+		g, a := k.annuity(rate, nper, when)
+		v = k.sub(zero, k.add(k.mul(pv, g), k.mul(pmt, a)))
+	}
+	return k.result(v, scale, mode)
+}
+
+// PMT returns the periodic payment of a loan with a present value pv and
+// a future value fv paid in nper periods at the given rate per period.
+// decimal.ErrDomain is returned if nper is not positive.
+func PMT(rate *decimal.Dec, nper int, pv, fv *decimal.Dec, when When, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	if nper <= 0 {
+		return decimal.Dec{}, decimal.ErrDomain
+	}
+	k := newCalc()
+	var v *decimal.Dec
+	if rate.Sign() == 0 {
+		// -(pv + fv) / nper
+		v = k.div(k.sub(zero, k.add(pv, fv)), decimal.New(int64(nper)))
+	} else {
+		// -(pv*g + fv) / a
+		g, a := k.annuity(rate, nper, when)
+		v = k.div(k.sub(zero, k.add(k.mul(pv, g), fv)), a)
+	}
+	return k.result(v, scale, mode)
+}
+
+// NPER returns the number of periods of an investment with a present value
+// pv, periodic payments pmt and a future value fv at the given rate per
+// period. decimal.ErrDomain is returned if no number of periods exists.
+func NPER(rate, pmt, pv, fv *decimal.Dec, when When, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k := newCalc()
+	var v *decimal.Dec
+	if rate.Sign() == 0 {
+		// -(pv + fv) / pmt
+		v = k.div(k.sub(zero, k.add(pv, fv)), pmt)
+	} else {
+		// ln((c - fv) / (c + pv)) / ln(1+rate) with c = pmt*(1+rate*when)/rate
+		c := k.div(pmt, rate)
+		if when == Begin {
+			c = k.mul(c, k.add(one, rate))
+		}
+		x := k.div(k.sub(c, fv), k.add(c, pv))
+		v = k.div(k.ln(x), k.ln(k.add(one, rate)))
+	}
+	return k.result(v, scale, mode)
+}
+
+// converged reports whether step rounds to zero at two more digits
+// than scale.
+func converged(step *decimal.Dec, scale uint8) bool {
+	if scale > 253 {
+		scale = 253
+	}
+	var z decimal.Dec
+	_, err := z.QuantizeChecked(step, scale+2, decimal.RoundHalfEven)
+	return err == nil && z.Sign() == 0
+}
+
+// RATE returns the interest rate per period of an investment with nper
+// periodic payments pmt, a present value pv and a future value fv.
+// The rate is found by Newton's method starting from guess, or from 10%
+// if guess is nil, and iterating until the step is below the last digit
+// of the requested scale. ErrNoConvergence is returned if no rate is found.
+func RATE(nper int, pmt, pv, fv *decimal.Dec, when When, guess *decimal.Dec, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	if nper <= 0 {
+		return decimal.Dec{}, decimal.ErrDomain
+	}
+	k := newCalc()
+	n := decimal.New(int64(nper))
+	w := decimal.New(int64(when))
+	r := new(decimal.Dec).Set(tenPercent)
+	if guess != nil {
+		r.Set(guess)
+	}
+	for i := 0; i < maxIterations && k.err == nil; i++ {
+		// f = pv*g + pmt*(1+r*w)*a + fv with a = (g-1)/r
+		var f, df *decimal.Dec
+		if r.Sign() == 0 {
+			// f' = pv*n + pmt*(w*n + n*(n-1)/2)
+			f = k.add(k.add(pv, k.mul(pmt, n)), fv)
+			t := k.div(k.mul(n, k.sub(n, one)), decimal.New(2))
+			df = k.add(k.mul(pv, n), k.mul(pmt, k.add(k.mul(w, n), t)))
+		} else {
+			// f' = pv*g' + pmt*(w*a + (1+r*w)*a')
+			// with g' = n*g/(1+r) and a' = (g'*r - (g-1))/r**2
+			r1 := k.add(one, r)
+			if r1.Sign() <= 0 {
+				break
+			}
+			g := k.pow(r1, nper)
+			dg := k.div(k.mul(n, g), r1)
+			a := k.div(k.sub(g, one), r)
+			da := k.div(k.sub(k.mul(dg, r), k.sub(g, one)), k.mul(r, r))
+			rw := k.add(one, k.mul(r, w))
+			f = k.add(k.add(k.mul(pv, g), k.mul(pmt, k.mul(rw, a))), fv)
+			df = k.add(k.mul(pv, dg), k.mul(pmt, k.add(k.mul(w, a), k.mul(rw, da))))
+		}
+		if k.err != nil || df.Sign() == 0 {
+			break
+		}
+		step := k.div(f, df)
+		r = k.sub(r, step)
+		if k.err == nil && converged(step, scale) {
+			return k.result(r, scale, mode)
+		}
+	}
+	if k.err != nil {
+		return decimal.Dec{}, k.err
+	}
+	return decimal.Dec{}, ErrNoConvergence
+}
+
+// NPV returns the net present value at the given rate per period of the
+// cash flows values, the first of them at the end of the first period.
+func NPV(rate *decimal.Dec, values []decimal.Dec, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	k := newCalc()
+	d := k.div(one, k.add(one, rate))
+	v := new(decimal.Dec)
+	t := d
+	for i := range values {
+		v = k.add(v, k.mul(&values[i], t))
+		t = k.mul(t, d)
+	}
+	return k.result(v, scale, mode)
+}
+
+// IRR returns the internal rate of return of the periodic cash flows
+// values, the rate at which their net present value is zero. The first
+// value is at the start of the first period. The rate is found by Newton's
+// method as by RATE. decimal.ErrDomain is returned if the values do not
+// contain both a positive and a negative cash flow.
+func IRR(values []decimal.Dec, guess *decimal.Dec, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	var pos, neg bool
+	for i := range values {
+		pos = pos || values[i].Sign() > 0
+		neg = neg || values[i].Sign() < 0
+	}
+	if !pos || !neg {
+		return decimal.Dec{}, decimal.ErrDomain
+	}
+	k := newCalc()
+	r := new(decimal.Dec).Set(tenPercent)
+	if guess != nil {
+		r.Set(guess)
+	}
+	for i := 0; i < maxIterations && k.err == nil; i++ {
+		// f = sum(v[i] * d**i), f' = -sum(i * v[i] * d**(i+1))
+		r1 := k.add(one, r)
+		if r1.Sign() <= 0 {
+			break
+		}
+		d := k.div(one, r1)
+		f, df := new(decimal.Dec), new(decimal.Dec)
+		t := one
+		for j := range values {
+			if j > 0 {
+				df = k.sub(df, k.mul(decimal.New(int64(j)), k.mul(&values[j], t)))
+			}
+			f = k.add(f, k.mul(&values[j], t))
+			t = k.mul(t, d)
+		}
+		df = k.mul(df, d)
+		if k.err != nil || df.Sign() == 0 {
+			break
+		}
+		step := k.div(f, df)
+		r = k.sub(r, step)
+		if k.err == nil && converged(step, scale) {
+			return k.result(r, scale, mode)
+		}
+	}
+	if k.err != nil {
+		return decimal.Dec{}, k.err
+	}
+	return decimal.Dec{}, ErrNoConvergence
+}
+
+// Effective returns the effective annual rate (APY) of the nominal annual
+// rate (APR) compounded npery times per year.
+// decimal.ErrDomain is returned if npery is not positive.
+func Effective(nominal *decimal.Dec, npery int, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	if npery <= 0 {
+		return decimal.Dec{}, decimal.ErrDomain
+	}
+	k := newCalc()
+	// (1 + nominal/npery)**npery - 1
+	v := k.sub(k.pow(k.add(one, k.div(nominal, decimal.New(int64(npery)))), npery), one)
+	return k.result(v, scale, mode)
+}
+
+// Nominal returns the nominal annual rate (APR) compounded npery times per
+// year of the effective annual rate (APY).
+// decimal.ErrDomain is returned if npery is not positive.
+func Nominal(effective *decimal.Dec, npery int, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	if npery <= 0 {
+		return decimal.Dec{}, decimal.ErrDomain
+	}
+	k := newCalc()
+	// npery * ((1 + effective)**(1/npery) - 1)
+	n := decimal.New(int64(npery))
+	v := k.mul(n, k.sub(k.powDec(k.add(one, effective), k.div(one, n)), one))
+	return k.result(v, scale, mode)
+}
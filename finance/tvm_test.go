@@ -0,0 +1,233 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import (
+	"testing"
+
+	"github.com/dimdin/decimal"
+)
+
+func dec(s string) *decimal.Dec {
+	var d decimal.Dec
+	if err := d.SetString(s); err != nil {
+		panic(err)
+	}
+	return &d
+}
+
+func decs(values ...string) []decimal.Dec {
+	s := make([]decimal.Dec, len(values))
+	for i, v := range values {
+		s[i] = *dec(v)
+	}
+	return s
+}
+
+func monthly(annual string) *decimal.Dec {
+	var r decimal.Dec
+	return r.Div(dec(annual), decimal.New(12), 30)
+}
+
+func TestPMT(t *testing.T) {
+	values := []struct {
+		rate   *decimal.Dec
+		nper   int
+		pv, fv string
+		when   When
+		r      string
+	}{
+		{monthly("0.05"), 360, "200000", "0", End, "-1073.64"},
+		{monthly("0.05"), 360, "200000", "0", Begin, "-1069.19"},
+		{dec("0"), 12, "1200", "0", End, "-100.00"},
+		{monthly("0.06"), 120, "0", "-50000", End, "305.10"},
+	}
+	for _, a := range values {
+		r, err := PMT(a.rate, a.nper, dec(a.pv), dec(a.fv), a.when, 2, decimal.RoundHalfUp)
+		if err != nil || r.String() != a.r {
+			t.Errorf("pmt %s %d %s %s got %s %v want %s", a.rate, a.nper, a.pv, a.fv, r, err, a.r)
+		}
+	}
+	if _, err := PMT(dec("0.01"), 0, dec("1"), dec("0"), End, 2, decimal.RoundHalfUp); err != decimal.ErrDomain {
+		t.Errorf("pmt of 0 periods got %v want %v", err, decimal.ErrDomain)
+	}
+}
+
+func TestFV(t *testing.T) {
+	values := []struct {
+		rate    *decimal.Dec
+		nper    int
+		pmt, pv string
+		when    When
+		r       string
+	}{
+		{monthly("0.06"), 10, "-200", "-500", Begin, "2581.40"},
+		{dec("0.005"), 120, "-100", "0", End, "16387.93"},
+		{dec("0"), 12, "-100", "-1000", End, "2200.00"},
+	}
+	for _, a := range values {
+		r, err := FV(a.rate, a.nper, dec(a.pmt), dec(a.pv), a.when, 2, decimal.RoundHalfUp)
+		if err != nil || r.String() != a.r {
+			t.Errorf("fv %s %d %s %s got %s %v want %s", a.rate, a.nper, a.pmt, a.pv, r, err, a.r)
+		}
+	}
+}
+
+func TestPV(t *testing.T) {
+	values := []struct {
+		rate    *decimal.Dec
+		nper    int
+		pmt, fv string
+		scale   uint8
+		r       string
+	}{
+		{monthly("0.08"), 240, "500", "0", 2, "-59777.15"},
+		{dec("0.1"), 5, "0", "-1000", 4, "620.9213"},
+		{dec("0"), 10, "-100", "0", 2, "1000.00"},
+	}
+	for _, a := range values {
+		r, err := PV(a.rate, a.nper, dec(a.pmt), dec(a.fv), End, a.scale, decimal.RoundHalfUp)
+		if err != nil || r.String() != a.r {
+			t.Errorf("pv %s %d %s %s got %s %v want %s", a.rate, a.nper, a.pmt, a.fv, r, err, a.r)
+		}
+	}
+}
+
+func TestNPER(t *testing.T) {
+	values := []struct {
+		rate        *decimal.Dec
+		pmt, pv, fv string
+		when        When
+		scale       uint8
+		r           string
+	}{
+		{dec("0.01"), "-100", "-1000", "10000", Begin, 6, "59.673866"},
+		{dec("0.01"), "-100", "1000", "0", End, 6, "10.588644"},
+		{dec("0"), "-100", "1000", "0", End, 2, "10.00"},
+	}
+	for _, a := range values {
+		r, err := NPER(a.rate, dec(a.pmt), dec(a.pv), dec(a.fv), a.when, a.scale, decimal.RoundHalfUp)
+		if err != nil || r.String() != a.r {
+			t.Errorf("nper %s %s %s %s got %s %v want %s", a.rate, a.pmt, a.pv, a.fv, r, err, a.r)
+		}
+	}
+	// the payments never repay the loan
+	if _, err := NPER(dec("0.01"), dec("-5"), dec("1000"), dec("0"), End, 2, decimal.RoundHalfUp); err != decimal.ErrDomain {
+		t.Errorf("nper got %v want %v", err, decimal.ErrDomain)
+	}
+}
+
+func TestRATE(t *testing.T) {
+	values := []struct {
+		nper        int
+		pmt, pv, fv string
+		when        When
+		scale       uint8
+		r           string
+	}{
+		{48, "-200", "8000", "0", End, 10, "0.0077014725"},
+		{360, "-1073.64", "200000", "0", End, 8, "0.00416664"},
+		{10, "0", "-1000", "2000", End, 12, "0.071773462536"},
+		{12, "-100", "1000", "0", Begin, 10, "0.0350315304"},
+		{12, "-100", "1200", "0", End, 10, "0.0000000000"},
+	}
+	for _, a := range values {
+		r, err := RATE(a.nper, dec(a.pmt), dec(a.pv), dec(a.fv), a.when, nil, a.scale, decimal.RoundHalfUp)
+		if err != nil || r.String() != a.r {
+			t.Errorf("rate %d %s %s %s got %s %v want %s", a.nper, a.pmt, a.pv, a.fv, r, err, a.r)
+		}
+	}
+	r, err := RATE(48, dec("-200"), dec("8000"), dec("0"), End, dec("0"), 10, decimal.RoundHalfUp)
+	if err != nil || r.String() != "0.0077014725" {
+		t.Errorf("rate from 0 got %s %v", r, err)
+	}
+	if _, err := RATE(10, dec("100"), dec("100"), dec("100"), End, nil, 4, decimal.RoundHalfUp); err != ErrNoConvergence {
+		t.Errorf("rate got %v want %v", err, ErrNoConvergence)
+	}
+}
+
+func TestNPV(t *testing.T) {
+	values := []struct {
+		rate   string
+		values []decimal.Dec
+		r      string
+	}{
+		{"0.1", decs("-10000", "3000", "4200", "6800"), "1188.44"},
+		{"0.08", decs("8000", "9200", "10000", "12000", "14500"), "41922.06"},
+		{"0", decs("1", "2", "3"), "6.00"},
+		{"0.1", nil, "0.00"},
+	}
+	for _, a := range values {
+		r, err := NPV(dec(a.rate), a.values, 2, decimal.RoundHalfUp)
+		if err != nil || r.String() != a.r {
+			t.Errorf("npv %s %v got %s %v want %s", a.rate, a.values, r, err, a.r)
+		}
+	}
+	if _, err := NPV(dec("-1"), decs("1"), 2, decimal.RoundHalfUp); err != decimal.ErrDivisionByZero {
+		t.Errorf("npv got %v want %v", err, decimal.ErrDivisionByZero)
+	}
+}
+
+func TestIRR(t *testing.T) {
+	values := []struct {
+		values []decimal.Dec
+		scale  uint8
+		r      string
+	}{
+		{decs("-70000", "12000", "15000", "18000", "21000", "26000"), 10, "0.0866309480"},
+		{decs("-70000", "12000", "15000", "18000", "21000"), 10, "-0.0212448483"},
+		{decs("-100", "110"), 4, "0.1000"},
+	}
+	for _, a := range values {
+		r, err := IRR(a.values, nil, a.scale, decimal.RoundHalfUp)
+		if err != nil || r.String() != a.r {
+			t.Errorf("irr %v got %s %v want %s", a.values, r, err, a.r)
+		}
+	}
+	for _, v := range [][]decimal.Dec{nil, decs("1", "2"), decs("-1", "0")} {
+		if _, err := IRR(v, nil, 4, decimal.RoundHalfUp); err != decimal.ErrDomain {
+			t.Errorf("irr %v got %v want %v", v, err, decimal.ErrDomain)
+		}
+	}
+}
+
+func TestEffectiveNominal(t *testing.T) {
+	values := []struct {
+		nominal   string
+		npery     int
+		scale     uint8
+		effective string
+	}{
+		{"0.0525", 4, 10, "0.0535426674"},
+		{"0.12", 12, 8, "0.12682503"},
+	}
+	for _, a := range values {
+		r, err := Effective(dec(a.nominal), a.npery, a.scale, decimal.RoundHalfUp)
+		if err != nil || r.String() != a.effective {
+			t.Errorf("effective %s %d got %s %v want %s", a.nominal, a.npery, r, err, a.effective)
+		}
+	}
+	inverse := []struct {
+		effective string
+		npery     int
+		scale     uint8
+		nominal   string
+	}{
+		{"0.053543", 12, 10, "0.0522722899"},
+		{"0.0535427", 4, 8, "0.05250003"},
+	}
+	for _, a := range inverse {
+		r, err := Nominal(dec(a.effective), a.npery, a.scale, decimal.RoundHalfUp)
+		if err != nil || r.String() != a.nominal {
+			t.Errorf("nominal %s %d got %s %v want %s", a.effective, a.npery, r, err, a.nominal)
+		}
+	}
+	if _, err := Effective(dec("0.1"), 0, 2, decimal.RoundHalfUp); err != decimal.ErrDomain {
+		t.Errorf("effective got %v want %v", err, decimal.ErrDomain)
+	}
+	if _, err := Nominal(dec("0.1"), -1, 2, decimal.RoundHalfUp); err != decimal.ErrDomain {
+		t.Errorf("nominal got %v want %v", err, decimal.ErrDomain)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "math/big"
+
+// FMA sets d to x*y+z computed exactly and rounded once to the given scale
+// according to mode and returns d.
+// If the result does not fit panics with Arithmetic overflow.
+func (d *Dec) FMA(x, y, z *Dec, scale uint8, mode RoundingMode) *Dec {
+	if !d.fma(x, y, z, scale, mode) {
+		overflow()
+	}
+	return d
+}
+
+// fma sets d to x*y+z rounded to the given scale according to mode
+// and reports whether the result fits. If it does not fit d is left
+// unchanged.
+func (d *Dec) fma(x, y, z *Dec, scale uint8, mode RoundingMode) bool {
+	pneg, mp, ps := mulWide(x, y)
+	var mz uint256
+	mz.setInt128(&z.coef)
+	zneg := z.coef.Sign() < 0
+	zs := int(z.scale)
+	// align the operands in 256 bits when possible
+	s, ok := ps, true
+	if ps > zs {
+		ok = mz.isZero() || ps-zs < len(pow10w) && mz.mul(&mz, &pow10w[ps-zs])
+	} else if zs > ps {
+		s = zs
+		ok = mp.isZero() || zs-ps < len(pow10w) && mp.mul(&mp, &pow10w[zs-ps])
+	}
+	var m uint256
+	neg := pneg
+	if ok {
+		if pneg == zneg {
+			ok = m.add(&mp, &mz)
+		} else if mp.cmp(&mz) >= 0 {
+			m.sub(&mp, &mz)
+		} else {
+			m.sub(&mz, &mp)
+			neg = zneg
+		}
+	}
+	if ok {
+		return d.setScaled(neg, &m, s, scale, mode)
+	}
+	// the exact sum does not fit in 256 bits
+	v := new(big.Int).Mul(bigInt(&x.coef), bigInt(&y.coef))
+	v.Mul(v, pow10big(s-ps))
+	v.Add(v, new(big.Int).Mul(bigInt(&z.coef), pow10big(s-zs)))
+	_, err := d.setFixed(v, s, scale, mode)
+	return err == nil
+}
+
+// FMA sets d to x*y+z computed exactly and rounded once to the given scale
+// according to mode and returns d.
+func (d *NullDec) FMA(x, y, z *NullDec, scale uint8, mode RoundingMode) *NullDec {
+	if x.Null() || y.Null() || z.Null() {
+		d.SetNull()
+	} else {
+		d.dec.FMA(&x.dec, &y.dec, &z.dec, scale, mode)
+		d.valid = true
+	}
+	return d
+}
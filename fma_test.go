@@ -0,0 +1,75 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFMA(t *testing.T) {
+	tiny := "0." + strings.Repeat("0", 200) + "1"
+	small := "0." + strings.Repeat("0", 99) + "1"
+	values := []struct {
+		x, y, z string
+		scale   uint8
+		mode    RoundingMode
+		r       string
+	}{
+		{"1000.00", "0.0125", "1000.00", 2, RoundHalfUp, "1012.50"},
+		{"1.23456789", "9.87654321", "-12.19326311", 10, RoundHalfUp, "0.0000000013"},
+		{"0.3333333333", "3", "0.0000000001", 10, RoundHalfUp, "1.0000000000"},
+		{"2.5", "0.1", "0", 0, RoundHalfEven, "0"},
+		{"-2.5", "0.1", "0", 0, RoundHalfEven, "0"},
+		{"1.05", "1.05", "-1", 4, RoundDown, "0.1025"},
+		{"0.00000000000000000001", "0.00000000000000000001", "1", 38, RoundCeiling, "1.00000000000000000000000000000000000001"},
+		{"1", "1", tiny, 2, RoundUp, "1.01"},
+		{"1", "1", "-" + tiny, 2, RoundDown, "0.99"},
+		{"-1", "1", tiny, 2, RoundFloor, "-1.00"},
+		{small, small, "1", 5, RoundUp, "1.00001"},
+		{"123456789012345678.9", "10", "0", 0, RoundHalfUp, "1234567890123456789"},
+	}
+	for _, a := range values {
+		var z Dec
+		z.FMA(parse(a.x), parse(a.y), parse(a.z), a.scale, a.mode)
+		if z.String() != a.r {
+			t.Errorf("%s * %s + %s scale %d %s got %s want %s", a.x, a.y, a.z, a.scale, a.mode, z, a.r)
+		}
+	}
+	// aliased operands
+	x := parse("1000.00")
+	x.FMA(x, parse("0.0125"), x, 2, RoundHalfUp)
+	if x.String() != "1012.50" {
+		t.Errorf("aliased fma got %s want 1012.50", x)
+	}
+	if "Arithmetic overflow" != panics(func() {
+		var z Dec
+		z.FMA(parse("12345678901234567890.1234567890"), parse("98765432109876543210.9876543210"), New(1), 2, RoundHalfUp)
+	}) {
+		t.Errorf("failed to panic with overflow")
+	}
+}
+
+func TestNullFMA(t *testing.T) {
+	values := []struct {
+		x, y, z string
+		r       string
+	}{
+		{"1000.00", "0.0125", "1000.00", "1012.50"},
+		{"", "0.0125", "1000.00", ""},
+		{"1000.00", "", "1000.00", ""},
+		{"1000.00", "0.0125", "", ""},
+	}
+	for _, a := range values {
+		var x, y, z, r NullDec
+		x.SetString(a.x)
+		y.SetString(a.y)
+		z.SetString(a.z)
+		r.FMA(&x, &y, &z, 2, RoundHalfUp)
+		if r.String() != a.r {
+			t.Errorf("%s * %s + %s got %s want %s", a.x, a.y, a.z, r, a.r)
+		}
+	}
+}
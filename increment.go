@@ -0,0 +1,61 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+// RoundToIncrement sets d to x rounded to a multiple of inc according to
+// mode and returns d. The sign of inc is ignored and the scale of d is the
+// scale of inc, so RoundToIncrement(x, 0.05, RoundHalfUp) rounds to the
+// nearest 5 cents.
+// If inc is zero panics with Division by zero.
+func (d *Dec) RoundToIncrement(x, inc *Dec, mode RoundingMode) *Dec {
+	var q, a Dec
+	a.Abs(inc)
+	q.DivMode(x, &a, 0, mode)
+	return d.Mul(&q, &a)
+}
+
+// IsMultipleOf reports whether x is an integer multiple of inc.
+// If inc is zero it reports whether x is zero.
+func (x Dec) IsMultipleOf(inc *Dec) bool {
+	if inc.coef.Sign() == 0 || x.coef.Sign() == 0 {
+		return x.coef.Sign() == 0
+	}
+	var a, b, q, r uint256
+	a.setInt128(&x.coef)
+	b.setInt128(&inc.coef)
+	k := int(inc.scale) - int(x.scale)
+	if k < 0 {
+		// a must be a multiple of b * 10**-k
+		if -k >= len(pow10w) || !b.mul(&b, &pow10w[-k]) {
+			return false
+		}
+		q.divmod(&a, &b, &r)
+		return r.isZero()
+	}
+	// a * 10**k mod b computed a few digits at a time
+	q.divmod(&a, &b, &r)
+	for k > 0 && !r.isZero() {
+		j := k
+		if j > MaxPrecision {
+			j = MaxPrecision
+		}
+		r.mul(&r, &pow10w[j])
+		q.divmod(&r, &b, &r)
+		k -= j
+	}
+	return r.isZero()
+}
+
+// RoundToIncrement sets d to x rounded to a multiple of inc according to
+// mode and returns d.
+func (d *NullDec) RoundToIncrement(x, inc *NullDec, mode RoundingMode) *NullDec {
+	if x.Null() || inc.Null() {
+		d.SetNull()
+	} else {
+		d.dec.RoundToIncrement(&x.dec, &inc.dec, mode)
+		d.valid = true
+	}
+	return d
+}
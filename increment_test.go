@@ -0,0 +1,104 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoundToIncrement(t *testing.T) {
+	values := []struct {
+		x, inc string
+		mode   RoundingMode
+		r      string
+	}{
+		{"1.02", "0.05", RoundHalfUp, "1.00"},
+		{"1.03", "0.05", RoundHalfUp, "1.05"},
+		{"1.025", "0.05", RoundHalfUp, "1.05"},
+		{"1.025", "0.05", RoundHalfEven, "1.00"},
+		{"1.075", "0.05", RoundHalfEven, "1.10"},
+		{"-1.03", "0.05", RoundFloor, "-1.05"},
+		{"-1.03", "-0.05", RoundCeiling, "-1.00"},
+		{"10.30", "0.25", RoundFloor, "10.25"},
+		{"10.30", "0.25", RoundCeiling, "10.50"},
+		{"10.25", "0.25", RoundUp, "10.25"},
+		{"1.23456", "0.0005", RoundHalfUp, "1.2345"},
+		{"1.23476", "0.0005", RoundHalfUp, "1.2350"},
+		{"1234.5", "10", RoundHalfUp, "1230"},
+		{"1235", "10", RoundHalfDown, "1230"},
+		{"7", "0.3", RoundDown, "6.9"},
+		{"0", "0.05", RoundUp, "0.00"},
+	}
+	for _, a := range values {
+		var z Dec
+		z.RoundToIncrement(parse(a.x), parse(a.inc), a.mode)
+		if z.String() != a.r {
+			t.Errorf("%s to %s %s got %s want %s", a.x, a.inc, a.mode, z, a.r)
+		}
+	}
+	x := parse("1.03")
+	x.RoundToIncrement(x, parse("0.05"), RoundHalfUp)
+	if x.String() != "1.05" {
+		t.Errorf("aliased 1.03 to 0.05 got %s want 1.05", x)
+	}
+	if "Division by zero" != panics(func() {
+		var z Dec
+		z.RoundToIncrement(New(1), New(0), RoundHalfUp)
+	}) {
+		t.Errorf("failed to panic with division by zero")
+	}
+}
+
+func TestIsMultipleOf(t *testing.T) {
+	values := []struct {
+		x, inc string
+		r      bool
+	}{
+		{"1.05", "0.05", true},
+		{"1.06", "0.05", false},
+		{"-10.50", "0.25", true},
+		{"10.30", "-0.25", false},
+		{"1", "0.5", true},
+		{"1", "0.3", false},
+		{"0.9", "0.3", true},
+		{"1230", "10", true},
+		{"1235", "10", false},
+		{"1200", "1.2", true},
+		{"0", "0.7", true},
+		{"0", "0", true},
+		{"1", "0", false},
+		{"1", "0." + strings.Repeat("0", 99) + "1", true},
+		{"1", "0." + strings.Repeat("0", 99) + "3", false},
+		{"0." + strings.Repeat("0", 99) + "1", "1", false},
+		{"140000000000000000000000000000000000007", "0." + strings.Repeat("0", 199) + "7", true},
+		{"170141183460469231731687303715884105727", "0." + strings.Repeat("0", 199) + "7", false},
+	}
+	for _, a := range values {
+		if r := parse(a.x).IsMultipleOf(parse(a.inc)); r != a.r {
+			t.Errorf("%s multiple of %s got %v want %v", a.x, a.inc, r, a.r)
+		}
+	}
+}
+
+func TestNullRoundToIncrement(t *testing.T) {
+	values := []struct {
+		x, inc string
+		r      string
+	}{
+		{"1.03", "0.05", "1.05"},
+		{"", "0.05", ""},
+		{"1.03", "", ""},
+	}
+	for _, a := range values {
+		var x, inc, z NullDec
+		x.SetString(a.x)
+		inc.SetString(a.inc)
+		z.RoundToIncrement(&x, &inc, RoundHalfUp)
+		if z.String() != a.r {
+			t.Errorf("%s to %s got %s want %s", a.x, a.inc, z, a.r)
+		}
+	}
+}
@@ -116,6 +116,14 @@ func (z *Int128) Neg(x *Int128) *Int128 {
 
 // Add sets z to the sum x+y and returns z.
 func (z *Int128) Add(x, y *Int128) *Int128 {
+	if !z.add(x, y) {
+		overflow()
+	}
+	return z
+}
+
+// add sets z to the sum x+y and reports whether it did not overflow.
+func (z *Int128) add(x, y *Int128) bool {
 	lo := x.lo
 	xneg := x.hi < 0
 	yneg := y.hi < 0
@@ -124,14 +132,19 @@ func (z *Int128) Add(x, y *Int128) *Int128 {
 	if z.lo < lo {
 		z.hi++
 	}
-	if xneg == yneg && xneg != (z.hi < 0) {
+	return xneg != yneg || xneg == (z.hi < 0)
+}
+
+// Sub sets z to the difference x-y and returns z.
+func (z *Int128) Sub(x, y *Int128) *Int128 {
+	if !z.sub(x, y) {
 		overflow()
 	}
 	return z
 }
 
-// Sub sets z to the difference x-y and returns z.
-func (z *Int128) Sub(x, y *Int128) *Int128 {
+// sub sets z to the difference x-y and reports whether it did not overflow.
+func (z *Int128) sub(x, y *Int128) bool {
 	lo := x.lo
 	xneg := x.hi < 0
 	yneg := y.hi < 0
@@ -140,10 +153,7 @@ func (z *Int128) Sub(x, y *Int128) *Int128 {
 	if z.lo > lo {
 		z.hi--
 	}
-	if xneg != yneg && xneg != (z.hi < 0) {
-		overflow()
-	}
-	return z
+	return xneg == yneg || xneg == (z.hi < 0)
 }
 
 // Lsh sets z = x << n and returns z.
@@ -223,7 +233,8 @@ func (z *Int128) SetBit(x *Int128, i int, b uint) *Int128 {
 const mask = 0xffffffff
 
 // Algorithm M from Knuth TAOCP Vol 2 4.3.1
-func mul(x, y, z *Int128) {
+// mul reports whether the product of the non negative x and y fits in z.
+func mul(x, y, z *Int128) bool {
 	var u, v [4]uint64
 	var w [8]uint64
 	u[0] = x.lo & mask
@@ -250,30 +261,41 @@ func mul(x, y, z *Int128) {
 		w[j+4] = k
 	}
 	if w[4] != 0 || w[5] != 0 || w[6] != 0 || w[7] != 0 || w[3]&0x80000000 != 0 {
-		overflow()
+		return false
 	}
 	z.lo = w[0] | (w[1] << 32)
 	z.hi = int64(w[2] | (w[3] << 32))
+	return true
 }
 
 // Mul sets z to the product x*y and returns z.
 func (z *Int128) Mul(x, y *Int128) *Int128 {
+	if !z.mul(x, y) {
+		overflow()
+	}
+	return z
+}
+
+// mul sets z to the product x*y and reports whether it did not overflow.
+func (z *Int128) mul(x, y *Int128) bool {
 	if (x.lo == 0 && x.hi == 0) ||
 		(y.lo == 0 && y.hi == 0) {
 		z.lo = 0
 		z.hi = 0
-		return z
+		return true
 	}
 	var u, v, w Int128
 	u.Abs(x)
 	v.Abs(y)
-	mul(&u, &v, &w)
+	if !mul(&u, &v, &w) {
+		return false
+	}
 	if (x.Sign() < 0) != (y.Sign() < 0) {
 		z.Neg(&w)
 	} else {
 		*z = w
 	}
-	return z
+	return true
 }
 
 func leadingZeros(x uint32) uint {
@@ -508,6 +530,20 @@ func (z *Int128) Power(x *Int128, n uint) *Int128 {
 	return z.Mul(z, &t)
 }
 
+// Sqrt sets z to the integer square root of x, the largest integer
+// such that z*z <= x, and returns z.
+// If x is negative, Sqrt panics.
+func (z *Int128) Sqrt(x *Int128) *Int128 {
+	if x.Sign() < 0 {
+		panic("Square root of negative number")
+	}
+	var m, r uint256
+	m.setInt128(x)
+	m.sqrt(&m, &r)
+	*z, _ = m.int128(false)
+	return z
+}
+
 // String returns the value of i
 func (i Int128) String() string {
 	return string(i.Bytes())
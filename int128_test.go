@@ -492,6 +492,43 @@ func TestIntPower(t *testing.T) {
 	}
 }
 
+func TestIntSqrt(t *testing.T) {
+	values := []struct {
+		x int64
+		r int64
+	}{
+		{0, 0},
+		{1, 1},
+		{3, 1},
+		{4, 2},
+		{99, 9},
+		{100, 10},
+		{math.MaxInt64, 3037000499},
+	}
+	for _, v := range values {
+		var x, z Int128
+		x.SetInt64(v.x)
+		z.Sqrt(&x)
+		if z.Int64() != v.r {
+			t.Errorf("Sqrt %v got %v want %v", v.x, z, v.r)
+		}
+	}
+}
+
+func TestRandIntSqrt(t *testing.T) {
+	var x, z, lo, hi Int128
+	x.lo = uint64(rand.Int63())
+	x.hi = rand.Int63()
+	z.Sqrt(&x)
+	lo.Mul(&z, &z)
+	var z1 Int128
+	z1.Add(&z, intOne)
+	hi.Mul(&z1, &z1)
+	if lo.Cmp(&x) > 0 || hi.Cmp(&x) <= 0 {
+		t.Errorf("Invalid square root of %s got %s", x, z)
+	}
+}
+
 func panics(f func()) (r interface{}) {
 	defer func() {
 		r = recover()
@@ -0,0 +1,213 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package money implements monetary amounts in ISO 4217 currencies.
+//
+// A Money is a decimal.Dec amount with exactly as many digits after the
+// decimal separator as the minor units of its currency. Results of
+// operations are rounded to the minor units of the currency. Operations
+// on amounts of different currencies return ErrCurrencyMismatch.
+//
+// In a database the amount of a Money is stored in a numeric column and
+// its currency in a separate column scanned into a Currency.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	"github.com/dimdin/decimal"
+)
+
+// ErrCurrencyMismatch is returned by operations on amounts of different
+// currencies.
+var ErrCurrencyMismatch = errors.New("Currency mismatch")
+
+// Money is an amount in a currency.
+type Money struct {
+	amount   decimal.Dec
+	currency Currency
+}
+
+// New returns the amount in the currency with the given code, rounded half
+// up to the minor units of the currency.
+func New(amount *decimal.Dec, code string) (*Money, error) {
+	c, err := Lookup(code)
+	if err != nil {
+		return nil, err
+	}
+	return new(Money).SetDec(amount, c, decimal.RoundHalfUp)
+}
+
+// Set sets z to x and returns z.
+func (z *Money) Set(x *Money) *Money {
+	*z = *x
+	return z
+}
+
+// SetDec sets z to the amount in currency c rounded to the minor units of
+// c according to mode and returns z.
+func (z *Money) SetDec(amount *decimal.Dec, c Currency, mode decimal.RoundingMode) (*Money, error) {
+	var a decimal.Dec
+	if _, err := a.QuantizeChecked(amount, c.minor, mode); err != nil {
+		return z, err
+	}
+	z.amount = a
+	z.currency = c
+	return z, nil
+}
+
+// SetMinorUnits sets z to the amount of units of the minor unit of
+// currency c, for example cents, and returns z.
+func (z *Money) SetMinorUnits(units int64, c Currency) *Money {
+	var unit decimal.Dec
+	unit.Power(decimal.New(10), int(c.minor))
+	z.amount.Div(decimal.New(units), &unit, c.minor)
+	z.currency = c
+	return z
+}
+
+// MinorUnits returns the amount of x in units of the minor unit of its
+// currency. decimal.ErrOverflow is returned if it does not fit in an int64.
+func (x Money) MinorUnits() (int64, error) {
+	i := x.amount.Coefficient()
+	var lo, hi decimal.Int128
+	lo.SetInt64(-1 << 63)
+	hi.SetInt64(1<<63 - 1)
+	if i.Cmp(&lo) < 0 || i.Cmp(&hi) > 0 {
+		return 0, decimal.ErrOverflow
+	}
+	return i.Int64(), nil
+}
+
+// Amount returns the amount of x.
+func (x Money) Amount() decimal.Dec {
+	return x.amount
+}
+
+// Currency returns the currency of x.
+func (x Money) Currency() Currency {
+	return x.currency
+}
+
+// Sign returns -1, 0 or +1 if x is negative, zero or positive.
+func (x Money) Sign() int {
+	return x.amount.Sign()
+}
+
+// Cmp compares x and y and returns -1, 0 or +1 if x is less than, equal
+// to or greater than y. ErrCurrencyMismatch is returned if the currencies
+// of x and y differ.
+func (x Money) Cmp(y *Money) (int, error) {
+	if x.currency != y.currency {
+		return 0, ErrCurrencyMismatch
+	}
+	return x.amount.Cmp(&y.amount), nil
+}
+
+// Abs sets z to |x| (the absolute value of x) and returns z.
+func (z *Money) Abs(x *Money) *Money {
+	z.amount.Abs(&x.amount)
+	z.currency = x.currency
+	return z
+}
+
+// Neg sets z to -x and returns z.
+func (z *Money) Neg(x *Money) *Money {
+	z.amount.Neg(&x.amount)
+	z.currency = x.currency
+	return z
+}
+
+// Add sets z to the sum x+y and returns z.
+func (z *Money) Add(x, y *Money) (*Money, error) {
+	if x.currency != y.currency {
+		return z, ErrCurrencyMismatch
+	}
+	if _, err := z.amount.AddChecked(&x.amount, &y.amount); err != nil {
+		return z, err
+	}
+	z.currency = x.currency
+	return z, nil
+}
+
+// Sub sets z to the difference x-y and returns z.
+func (z *Money) Sub(x, y *Money) (*Money, error) {
+	if x.currency != y.currency {
+		return z, ErrCurrencyMismatch
+	}
+	if _, err := z.amount.SubChecked(&x.amount, &y.amount); err != nil {
+		return z, err
+	}
+	z.currency = x.currency
+	return z, nil
+}
+
+// Mul sets z to the product x*y rounded to the minor units of the
+// currency of x according to mode and returns z.
+func (z *Money) Mul(x *Money, y *decimal.Dec, mode decimal.RoundingMode) (*Money, error) {
+	if _, err := z.amount.MulRoundChecked(&x.amount, y, x.currency.minor, mode); err != nil {
+		return z, err
+	}
+	z.currency = x.currency
+	return z, nil
+}
+
+// Div sets z to the quotient x/y rounded to the minor units of the
+// currency of x according to mode and returns z.
+func (z *Money) Div(x *Money, y *decimal.Dec, mode decimal.RoundingMode) (*Money, error) {
+	if _, err := z.amount.DivModeChecked(&x.amount, y, x.currency.minor, mode); err != nil {
+		return z, err
+	}
+	z.currency = x.currency
+	return z, nil
+}
+
+// String returns the amount of x followed by the code of its currency,
+// for example "12.34 USD".
+func (x Money) String() string {
+	return x.amount.String() + " " + x.currency.code
+}
+
+// SetString sets z to the value of s, an amount followed by a currency
+// code as returned by String. The amount is rounded half up to the minor
+// units of the currency.
+func (z *Money) SetString(s string) error {
+	i := strings.LastIndexByte(s, ' ')
+	if i < 0 {
+		return errors.New("Invalid money " + s)
+	}
+	c, err := Lookup(s[i+1:])
+	if err != nil {
+		return err
+	}
+	var a decimal.Dec
+	if err := a.SetString(strings.TrimSpace(s[:i])); err != nil {
+		return err
+	}
+	_, err = z.SetDec(&a, c, decimal.RoundHalfUp)
+	return err
+}
+
+// Scan implements the database Scanner interface.
+// The value is the amount as scanned by decimal.Dec, rounded half up to
+// the minor units of the currency of z that must already be set.
+func (z *Money) Scan(value interface{}) error {
+	if z.currency.code == "" {
+		return errors.New("Cannot Scan into Money without currency")
+	}
+	var a decimal.Dec
+	if err := a.Scan(value); err != nil {
+		return err
+	}
+	_, err := z.SetDec(&a, z.currency, decimal.RoundHalfUp)
+	return err
+}
+
+// Value implements the database driver Valuer interface.
+// The value is the amount of x without its currency.
+func (x Money) Value() (driver.Value, error) {
+	return x.amount.Value()
+}
@@ -0,0 +1,216 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package money
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/dimdin/decimal"
+)
+
+func dec(s string) *decimal.Dec {
+	var d decimal.Dec
+	if err := d.SetString(s); err != nil {
+		panic(err)
+	}
+	return &d
+}
+
+func money(s string) *Money {
+	var m Money
+	if err := m.SetString(s); err != nil {
+		panic(err)
+	}
+	return &m
+}
+
+func TestLookup(t *testing.T) {
+	values := []struct {
+		code  string
+		minor uint8
+	}{
+		{"JPY", 0},
+		{"USD", 2},
+		{"EUR", 2},
+		{"BHD", 3},
+		{"CLF", 4},
+	}
+	for _, a := range values {
+		c, err := Lookup(a.code)
+		if err != nil || c.Code() != a.code || c.String() != a.code || c.MinorUnits() != a.minor {
+			t.Errorf("%s got %s %d %v want %d", a.code, c, c.MinorUnits(), err, a.minor)
+		}
+	}
+	for _, code := range []string{"", "usd", "XAU", "ABC"} {
+		if _, err := Lookup(code); err != ErrUnknownCurrency {
+			t.Errorf("%q got %v want %v", code, err, ErrUnknownCurrency)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	values := []struct {
+		amount, code string
+		r            string
+		units        int64
+	}{
+		{"12.345", "USD", "12.35 USD", 1235},
+		{"-12.345", "USD", "-12.35 USD", -1235},
+		{"12", "USD", "12.00 USD", 1200},
+		{"1234.5", "JPY", "1235 JPY", 1235},
+		{"1.2345", "BHD", "1.235 BHD", 1235},
+		{"0", "EUR", "0.00 EUR", 0},
+	}
+	for _, a := range values {
+		m, err := New(dec(a.amount), a.code)
+		if err != nil || m.String() != a.r {
+			t.Errorf("%s %s got %s %v want %s", a.amount, a.code, m, err, a.r)
+			continue
+		}
+		if units, err := m.MinorUnits(); err != nil || units != a.units {
+			t.Errorf("%s minor units got %d %v want %d", m, units, err, a.units)
+		}
+		var n Money
+		if n.SetMinorUnits(a.units, m.Currency()); n != *m {
+			t.Errorf("%d %s got %s want %s", a.units, a.code, n, m)
+		}
+	}
+	if _, err := New(dec("1"), "XXX"); err != ErrUnknownCurrency {
+		t.Errorf("got %v want %v", err, ErrUnknownCurrency)
+	}
+	m, err := New(dec("92233720368547758.08"), "USD")
+	if _, err2 := m.MinorUnits(); err != nil || err2 != decimal.ErrOverflow {
+		t.Errorf("minor units overflow got %v %v", err, err2)
+	}
+	usd, _ := Lookup("USD")
+	if _, err := m.SetDec(dec("12.345"), usd, decimal.RoundDown); err != nil || m.String() != "12.34 USD" {
+		t.Errorf("round down got %s %v", m, err)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	values := []struct {
+		x, op, y string
+		r        string
+		err      error
+	}{
+		{"1.10 USD", "+", "2.25 USD", "3.35 USD", nil},
+		{"1.10 USD", "-", "2.25 USD", "-1.15 USD", nil},
+		{"1.10 USD", "+", "2.25 EUR", "", ErrCurrencyMismatch},
+		{"1.10 USD", "-", "2.25 EUR", "", ErrCurrencyMismatch},
+		{"1.10 USD", "*", "1.235", "1.36 USD", nil},
+		{"100 JPY", "*", "0.005", "1 JPY", nil},
+		{"1.10 USD", "/", "3", "0.37 USD", nil},
+		{"1.10 USD", "/", "0", "", decimal.ErrDivisionByZero},
+		{"1701411834604692317316873037158841057.27 USD", "+", "0.01 USD", "", decimal.ErrOverflow},
+		{"-1701411834604692317316873037158841057.27 USD", "-", "0.02 USD", "", decimal.ErrOverflow},
+		{"1701411834604692317316873037158841057.27 USD", "*", "2", "", decimal.ErrOverflow},
+	}
+	for _, a := range values {
+		x := money(a.x)
+		var z Money
+		var err error
+		switch a.op {
+		case "+":
+			_, err = z.Add(x, money(a.y))
+		case "-":
+			_, err = z.Sub(x, money(a.y))
+		case "*":
+			_, err = z.Mul(x, dec(a.y), decimal.RoundHalfUp)
+		case "/":
+			_, err = z.Div(x, dec(a.y), decimal.RoundHalfUp)
+		}
+		if err != a.err {
+			t.Errorf("%s %s %s got error %v want %v", a.x, a.op, a.y, err, a.err)
+		}
+		if err == nil && z.String() != a.r {
+			t.Errorf("%s %s %s got %s want %s", a.x, a.op, a.y, z, a.r)
+		}
+		if err != nil && z != (Money{}) {
+			t.Errorf("%s %s %s alters z to %s", a.x, a.op, a.y, z)
+		}
+	}
+
+	x, y := money("-1.50 EUR"), money("1.49 EUR")
+	if c, err := x.Cmp(y); c != -1 || err != nil {
+		t.Errorf("cmp got %d %v", c, err)
+	}
+	if _, err := x.Cmp(money("1 USD")); err != ErrCurrencyMismatch {
+		t.Errorf("cmp got %v want %v", err, ErrCurrencyMismatch)
+	}
+	var z Money
+	if z.Abs(x); z.String() != "1.50 EUR" || z.Sign() != 1 {
+		t.Errorf("abs got %s", z)
+	}
+	if z.Neg(y); z.String() != "-1.49 EUR" || z.Sign() != -1 {
+		t.Errorf("neg got %s", z)
+	}
+	if z.Set(y); z.Amount().String() != "1.49" || z.Currency().Code() != "EUR" {
+		t.Errorf("set got %s", z)
+	}
+}
+
+func TestScan(t *testing.T) {
+	values := []struct {
+		v    interface{}
+		code string
+		r    string
+		err  bool
+	}{
+		{"12.345", "USD", "12.35", false},
+		{[]byte("-1"), "JPY", "-1", false},
+		{int64(5), "BHD", "5.000", false},
+		{float64(0.125), "EUR", "0.13", false},
+		{"12.34 USD", "USD", "", true},
+		{"x", "USD", "", true},
+		{nil, "USD", "", true},
+		{"12.34", "", "", true},
+	}
+	for _, a := range values {
+		var m Money
+		if a.code != "" {
+			c, _ := Lookup(a.code)
+			m.SetMinorUnits(0, c)
+		}
+		err := m.Scan(a.v)
+		if (err != nil) != a.err || err == nil && m.Amount().String() != a.r {
+			t.Errorf("%v got %s %v want %s", a.v, m, err, a.r)
+		}
+		if err != nil {
+			continue
+		}
+		if m.Currency().Code() != a.code {
+			t.Errorf("%v currency got %s want %s", a.v, m.Currency(), a.code)
+		}
+		// the amount round trips through a numeric column
+		v, err := m.Value()
+		if err != nil {
+			t.Errorf("%v value got %v", a.v, err)
+			continue
+		}
+		var z Money
+		z.SetMinorUnits(0, m.Currency())
+		if err := z.Scan(v); err != nil || z != m {
+			t.Errorf("%v round trip got %s %v want %s", a.v, z, err, m)
+		}
+	}
+}
+
+func TestScanCurrency(t *testing.T) {
+	var c Currency
+	if err := c.Scan([]byte("KWD")); err != nil || c.Code() != "KWD" || c.MinorUnits() != 3 {
+		t.Errorf("scan KWD got %s %v", c, err)
+	}
+	if v, err := c.Value(); err != nil || v != driver.Value("KWD") {
+		t.Errorf("value got %v %v", v, err)
+	}
+	if err := c.Scan("XXX"); err != ErrUnknownCurrency {
+		t.Errorf("scan XXX got %v", err)
+	}
+	if err := c.Scan(nil); err == nil {
+		t.Error("scan null got no error")
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package money
+
+import (
+	"sync"
+
+	"github.com/dimdin/decimal"
+)
+
+// MissingRateError is returned when no exchange rate converts From to To.
+type MissingRateError struct {
+	From, To string
+}
+
+func (e *MissingRateError) Error() string {
+	return "Missing exchange rate " + e.From + "/" + e.To
+}
+
+type pair struct {
+	from, to string
+}
+
+// Rates is a table of exchange rates between currencies.
+//
+// The rate from one currency to another is the rate set for the pair, the
+// inverse of the rate set for the reverse pair, or the cross rate through
+// the base currency. Rates are rounded half even to the precision of the
+// table in significant digits.
+//
+// A Rates is safe for concurrent use.
+type Rates struct {
+	base      string
+	precision uint8
+	mu        sync.RWMutex
+	rates     map[pair]decimal.Dec
+}
+
+// NewRates returns an empty table with cross rates through the currency
+// base, none if base is empty, and rates rounded to precision significant
+// digits. A precision of 0 or more than decimal.MaxPrecision means
+// decimal.MaxPrecision.
+func NewRates(base string, precision uint8) *Rates {
+	if precision == 0 || precision > decimal.MaxPrecision {
+		precision = decimal.MaxPrecision
+	}
+	return &Rates{base: base, precision: precision, rates: map[pair]decimal.Dec{}}
+}
+
+// Set sets the rate of the pair from/to, the amount of to for one unit of
+// from. decimal.ErrDomain is returned if the rate is not positive or the
+// currencies are the same.
+func (r *Rates) Set(from, to string, rate *decimal.Dec) error {
+	if rate.Sign() <= 0 || from == to {
+		return decimal.ErrDomain
+	}
+	r.mu.Lock()
+	r.rates[pair{from, to}] = *rate
+	r.mu.Unlock()
+	return nil
+}
+
+// Delete removes the rate of the pair from/to.
+func (r *Rates) Delete(from, to string) {
+	r.mu.Lock()
+	delete(r.rates, pair{from, to})
+	r.mu.Unlock()
+}
+
+// leg returns the rate from from to to as a numerator, or its inverse as a
+// denominator. The caller holds the lock.
+func (r *Rates) leg(from, to string) (num, den decimal.Dec, ok bool) {
+	if v, ok := r.rates[pair{from, to}]; ok {
+		return v, *decimal.New(1), true
+	}
+	if v, ok := r.rates[pair{to, from}]; ok {
+		return *decimal.New(1), v, true
+	}
+	return num, den, false
+}
+
+// Rate returns the rate from from to to, the amount of to for one unit of
+// from. A *MissingRateError is returned if there is no such rate.
+func (r *Rates) Rate(from, to string) (decimal.Dec, error) {
+	if from == to {
+		return *decimal.New(1), nil
+	}
+	r.mu.RLock()
+	num, den, ok := r.leg(from, to)
+	var n2, d2 decimal.Dec
+	cross := !ok && r.base != "" && from != r.base && to != r.base
+	if cross {
+		// triangulate through the base currency
+		if num, den, ok = r.leg(from, r.base); ok {
+			n2, d2, ok = r.leg(r.base, to)
+		}
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return decimal.Dec{}, &MissingRateError{from, to}
+	}
+	var z decimal.Dec
+	if cross {
+		c := decimal.NewContext(decimal.MaxPrecision, decimal.RoundHalfEven)
+		if _, err := c.Mul(&num, &num, &n2); err != nil {
+			return z, err
+		}
+		if _, err := c.Mul(&den, &den, &d2); err != nil {
+			return z, err
+		}
+	}
+	_, err := decimal.NewContext(r.precision, decimal.RoundHalfEven).Div(&z, &num, &den)
+	return z, err
+}
+
+// Convert returns the amount in from converted to to, rounded to the given
+// scale according to mode.
+func (r *Rates) Convert(amount *decimal.Dec, from, to string, scale uint8, mode decimal.RoundingMode) (decimal.Dec, error) {
+	rate, err := r.Rate(from, to)
+	if err != nil {
+		return decimal.Dec{}, err
+	}
+	var z decimal.Dec
+	_, err = z.MulRoundChecked(amount, &rate, scale, mode)
+	return z, err
+}
+
+// Exchange returns x converted to the currency to, rounded to the minor
+// units of to according to mode.
+func (r *Rates) Exchange(x *Money, to Currency, mode decimal.RoundingMode) (Money, error) {
+	var z Money
+	a, err := r.Convert(&x.amount, x.currency.code, to.code, to.minor, mode)
+	if err != nil {
+		return z, err
+	}
+	z.amount = a
+	z.currency = to
+	return z, nil
+}
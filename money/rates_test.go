@@ -0,0 +1,140 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package money
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/dimdin/decimal"
+)
+
+func newRates(t *testing.T, base string, precision uint8) *Rates {
+	r := NewRates(base, precision)
+	if err := r.Set("EUR", "USD", dec("1.0850")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Set("USD", "JPY", dec("149.32")); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRate(t *testing.T) {
+	values := []struct {
+		precision uint8
+		from, to  string
+		r         string
+	}{
+		{10, "EUR", "USD", "1.0850"},
+		{10, "USD", "EUR", "0.9216589862"},
+		{10, "JPY", "USD", "0.006697026520"},
+		{10, "EUR", "JPY", "162.012200"},
+		{10, "JPY", "EUR", "0.006172374673"},
+		{4, "JPY", "EUR", "0.006172"},
+		{4, "EUR", "JPY", "162.0"},
+		{0, "USD", "EUR", "0.92165898617511520737327188940092165899"},
+		{10, "GBP", "GBP", "1"},
+	}
+	for _, a := range values {
+		r := newRates(t, "USD", a.precision)
+		rate, err := r.Rate(a.from, a.to)
+		if err != nil || rate.String() != a.r {
+			t.Errorf("%d %s/%s got %s %v want %s", a.precision, a.from, a.to, rate, err, a.r)
+		}
+	}
+
+	r := newRates(t, "", 10)
+	missing := []struct{ from, to string }{
+		{"EUR", "JPY"},
+		{"EUR", "GBP"},
+	}
+	for _, a := range missing {
+		_, err := r.Rate(a.from, a.to)
+		var e *MissingRateError
+		if !errors.As(err, &e) || e.From != a.from || e.To != a.to {
+			t.Errorf("%s/%s got %v", a.from, a.to, err)
+		}
+	}
+	r = newRates(t, "USD", 10)
+	if _, err := r.Rate("GBP", "JPY"); err == nil || err.Error() != "Missing exchange rate GBP/JPY" {
+		t.Errorf("GBP/JPY got %v", err)
+	}
+	r.Delete("USD", "JPY")
+	if _, err := r.Rate("EUR", "JPY"); err == nil {
+		t.Errorf("EUR/JPY after delete got %v", err)
+	}
+	for _, rate := range []string{"0", "-1.2"} {
+		if err := r.Set("EUR", "GBP", dec(rate)); err != decimal.ErrDomain {
+			t.Errorf("rate %s got %v want %v", rate, err, decimal.ErrDomain)
+		}
+	}
+	if err := r.Set("EUR", "EUR", dec("1")); err != decimal.ErrDomain {
+		t.Errorf("EUR/EUR got %v want %v", err, decimal.ErrDomain)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	r := newRates(t, "USD", 10)
+	values := []struct {
+		amount, from, to string
+		scale            uint8
+		mode             decimal.RoundingMode
+		r                string
+	}{
+		{"2500.00", "USD", "EUR", 2, decimal.RoundHalfUp, "2304.15"},
+		{"2500.00", "USD", "EUR", 2, decimal.RoundDown, "2304.14"},
+		{"100", "EUR", "JPY", 0, decimal.RoundHalfUp, "16201"},
+		{"100", "EUR", "JPY", 0, decimal.RoundCeiling, "16202"},
+	}
+	for _, a := range values {
+		v, err := r.Convert(dec(a.amount), a.from, a.to, a.scale, a.mode)
+		if err != nil || v.String() != a.r {
+			t.Errorf("%s %s/%s got %s %v want %s", a.amount, a.from, a.to, v, err, a.r)
+		}
+	}
+	if _, err := r.Convert(dec("1"), "EUR", "GBP", 2, decimal.RoundHalfUp); err == nil {
+		t.Error("EUR/GBP converted")
+	}
+	if _, err := r.Convert(dec("170141183460469231731687303715884105727"), "EUR", "USD", 0, decimal.RoundHalfUp); err != decimal.ErrOverflow {
+		t.Errorf("overflow got %v want %v", err, decimal.ErrOverflow)
+	}
+
+	jpy, _ := Lookup("JPY")
+	m, err := r.Exchange(money("100.00 EUR"), jpy, decimal.RoundHalfUp)
+	if err != nil || m.String() != "16201 JPY" {
+		t.Errorf("exchange got %s %v", m, err)
+	}
+	bhd, _ := Lookup("BHD")
+	if _, err := r.Exchange(money("1 EUR"), bhd, decimal.RoundHalfUp); err == nil {
+		t.Error("EUR/BHD exchanged")
+	}
+}
+
+func TestRatesConcurrent(t *testing.T) {
+	r := newRates(t, "USD", 10)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.Set("GBP", "USD", dec("1.27"))
+				r.Delete("GBP", "USD")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := r.Rate("EUR", "JPY"); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
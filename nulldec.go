@@ -221,14 +221,33 @@ func (d *NullDec) Mul(x, y *NullDec) *NullDec {
 	return d
 }
 
+// MulRound sets d to the product x*y rounded to the given scale according
+// to mode and returns d.
+func (d *NullDec) MulRound(x, y *NullDec, scale uint8, mode RoundingMode) *NullDec {
+	if x.Null() || y.Null() {
+		d.SetNull()
+	} else {
+		d.dec.MulRound(&x.dec, &y.dec, scale, mode)
+		d.valid = true
+	}
+	return d
+}
+
 // Div sets d to the rounded quotient x/y and returns d.
 // If y is zero panics with Division by zero.
 // The resulting value is rounded half up to the given scale.
 func (d *NullDec) Div(x, y *NullDec, scale uint8) *NullDec {
+	return d.DivMode(x, y, scale, RoundHalfUp)
+}
+
+// DivMode sets d to the quotient x/y rounded to the given scale
+// according to mode and returns d.
+// If y is zero panics with Division by zero.
+func (d *NullDec) DivMode(x, y *NullDec, scale uint8, mode RoundingMode) *NullDec {
 	if x.Null() || y.Null() {
 		d.SetNull()
 	} else {
-		d.dec.Div(&x.dec, &y.dec, scale)
+		d.dec.DivMode(&x.dec, &y.dec, scale, mode)
 		d.valid = true
 	}
 	return d
@@ -236,18 +255,102 @@ func (d *NullDec) Div(x, y *NullDec, scale uint8) *NullDec {
 
 // Round d half up to the given scale and returns d
 func (d *NullDec) Round(scale uint8) *NullDec {
+	return d.RoundMode(scale, RoundHalfUp)
+}
+
+// RoundMode rounds d to the given scale according to mode and returns d.
+func (d *NullDec) RoundMode(scale uint8, mode RoundingMode) *NullDec {
+	if !d.Null() {
+		d.dec.RoundMode(scale, mode)
+	}
+	return d
+}
+
+// RoundSig rounds d to n significant digits according to mode and
+// returns d.
+func (d *NullDec) RoundSig(n uint8, mode RoundingMode) *NullDec {
+	if !d.Null() {
+		d.dec.RoundSig(n, mode)
+	}
+	return d
+}
+
+// Precision returns the number of digits of the coefficient of d,
+// zero if d is null.
+func (d NullDec) Precision() int {
+	if d.Null() {
+		return 0
+	}
+	return d.dec.Precision()
+}
+
+// Trunc truncates d toward zero to the given scale and returns d.
+func (d *NullDec) Trunc(scale uint8) *NullDec {
+	if !d.Null() {
+		d.dec.Trunc(scale)
+	}
+	return d
+}
+
+// Ceil rounds d toward +∞ to the given scale and returns d.
+func (d *NullDec) Ceil(scale uint8) *NullDec {
 	if !d.Null() {
-		d.dec.Round(scale)
+		d.dec.Ceil(scale)
 	}
 	return d
 }
 
+// Floor rounds d toward -∞ to the given scale and returns d.
+func (d *NullDec) Floor(scale uint8) *NullDec {
+	if !d.Null() {
+		d.dec.Floor(scale)
+	}
+	return d
+}
+
+// Quantize sets d to x with exactly the given scale and returns d.
+// If scale is less than the scale of x the value is rounded according
+// to mode, otherwise it is padded with zeros.
+func (d *NullDec) Quantize(x *NullDec, scale uint8, mode RoundingMode) *NullDec {
+	if x.Null() {
+		d.SetNull()
+	} else {
+		d.dec.Quantize(&x.dec, scale, mode)
+		d.valid = true
+	}
+	return d
+}
+
+// SetScale sets d to its value with exactly the given scale, rounding
+// half up if the scale is reduced, and returns d.
+func (d *NullDec) SetScale(scale uint8) *NullDec {
+	return d.Quantize(d, scale, RoundHalfUp)
+}
+
 // Power sets d = x^n and returns d
 func (d *NullDec) Power(x *NullDec, n int) *NullDec {
+	return d.PowerMode(x, n, RoundHalfUp)
+}
+
+// PowerMode sets d = x^n rounding according to mode and returns d.
+func (d *NullDec) PowerMode(x *NullDec, n int, mode RoundingMode) *NullDec {
+	if x.Null() {
+		d.SetNull()
+	} else {
+		d.dec.PowerMode(&x.dec, n, mode)
+		d.valid = true
+	}
+	return d
+}
+
+// Sqrt sets d to the square root of x rounded to the given scale
+// according to mode and returns d.
+// If x is negative, Sqrt panics.
+func (d *NullDec) Sqrt(x *NullDec, scale uint8, mode RoundingMode) *NullDec {
 	if x.Null() {
 		d.SetNull()
 	} else {
-		d.dec.Power(&x.dec, n)
+		d.dec.Sqrt(&x.dec, scale, mode)
 		d.valid = true
 	}
 	return d
@@ -178,6 +178,70 @@ func TestNullMul(t *testing.T) {
 	}
 }
 
+func TestNullTruncCeilFloor(t *testing.T) {
+	values := []struct {
+		x                  string
+		trunc, ceil, floor string
+	}{
+		{"-1.234", "-1.23", "-1.23", "-1.24"},
+		{"", "", "", ""},
+	}
+	for _, a := range values {
+		var x, y, z NullDec
+		x.SetString(a.x)
+		y.SetString(a.x)
+		z.SetString(a.x)
+		x.Trunc(2)
+		y.Ceil(2)
+		z.Floor(2)
+		if x.String() != a.trunc || y.String() != a.ceil || z.String() != a.floor {
+			t.Errorf("%s got %s %s %s want %s %s %s", a.x, x, y, z, a.trunc, a.ceil, a.floor)
+		}
+	}
+}
+
+func TestNullRoundSig(t *testing.T) {
+	values := []struct {
+		x    string
+		r    string
+		prec int
+	}{
+		{"0.000123456", "0.000123", 6},
+		{"", "", 0},
+	}
+	for _, a := range values {
+		var x NullDec
+		x.SetString(a.x)
+		if p := x.Precision(); p != a.prec {
+			t.Errorf("precision %s got %d want %d", a.x, p, a.prec)
+		}
+		if x.RoundSig(3, RoundHalfUp); x.String() != a.r {
+			t.Errorf("%s to 3 significant digits got %s want %s", a.x, x, a.r)
+		}
+	}
+}
+
+func TestNullMulRound(t *testing.T) {
+	values := []struct {
+		x string
+		y string
+		z string
+	}{
+		{"1.25", "0.5", "0.63"},
+		{"1", "", ""},
+		{"", "1", ""},
+	}
+	for _, a := range values {
+		var x, y, z NullDec
+		x.SetString(a.x)
+		y.SetString(a.y)
+		z.MulRound(&x, &y, 2, RoundHalfUp)
+		if a.z != z.String() {
+			t.Errorf("%s * %s got %s want %s", a.x, a.y, z.String(), a.z)
+		}
+	}
+}
+
 func TestNullRound(t *testing.T) {
 	values := []struct {
 		x     string
@@ -219,6 +283,73 @@ func TestNullDiv(t *testing.T) {
 	}
 }
 
+func TestNullRoundMode(t *testing.T) {
+	values := []struct {
+		x     string
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"1.225", 2, RoundHalfEven, "1.22"},
+		{"1.235", 2, RoundHalfEven, "1.24"},
+		{"-1.221", 2, RoundFloor, "-1.23"},
+		{"", 2, RoundFloor, ""},
+	}
+	for i, a := range values {
+		var x NullDec
+		x.SetString(a.x)
+		x.RoundMode(a.scale, a.mode)
+		if x.String() != a.r {
+			t.Errorf("#%d got %s want %s", i, x, a.r)
+		}
+	}
+}
+
+func TestNullDivMode(t *testing.T) {
+	values := []struct {
+		x, y  string
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"1", "8", 2, RoundHalfEven, "0.12"},
+		{"1", "3", 2, RoundUp, "0.34"},
+		{"", "3", 2, RoundUp, ""},
+	}
+	for _, a := range values {
+		var x, y, z NullDec
+		x.SetString(a.x)
+		y.SetString(a.y)
+		z.DivMode(&x, &y, a.scale, a.mode)
+		if z.String() != a.r {
+			t.Errorf("%s / %s round %d %s got %s want %s", a.x, a.y, a.scale, a.mode, z, a.r)
+		}
+	}
+}
+
+func TestNullQuantize(t *testing.T) {
+	values := []struct {
+		x     string
+		scale uint8
+		r     string
+	}{
+		{"1.5", 3, "1.500"},
+		{"1.2345", 2, "1.23"},
+		{"", 2, ""},
+	}
+	for _, a := range values {
+		var x, z NullDec
+		x.SetString(a.x)
+		z.Quantize(&x, a.scale, RoundHalfUp)
+		if z.String() != a.r {
+			t.Errorf("quantize %s to %d got %s want %s", a.x, a.scale, z, a.r)
+		}
+		if x.SetScale(a.scale).String() != a.r {
+			t.Errorf("set scale of %s to %d got %s want %s", a.x, a.scale, x, a.r)
+		}
+	}
+}
+
 func TestNullPower(t *testing.T) {
 	values := []struct {
 		x string
@@ -243,6 +374,24 @@ func TestNullPower(t *testing.T) {
 	}
 }
 
+func TestNullSqrt(t *testing.T) {
+	values := []struct {
+		x string
+		r string
+	}{
+		{"2", "1.41"},
+		{"", ""},
+	}
+	for _, a := range values {
+		var x, z NullDec
+		x.SetString(a.x)
+		z.Sqrt(&x, 2, RoundHalfUp)
+		if z.String() != a.r {
+			t.Errorf("sqrt %s got %s want %s", a.x, z, a.r)
+		}
+	}
+}
+
 func TestNullFloat64(t *testing.T) {
 	values := []string{
 		"12.34",
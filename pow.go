@@ -0,0 +1,210 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"math/big"
+)
+
+// PowDec sets d to x**y and returns d.
+// The resulting value is rounded half up to the given scale.
+func (d *Dec) PowDec(x, y *Dec, scale uint8) (*Dec, error) {
+	return d.PowDecMode(x, y, scale, RoundHalfUp)
+}
+
+// PowDecMode sets d to x**y rounded to the given scale according to mode
+// and returns d. ErrDomain is returned for a negative x with a non integral
+// y, ErrDivisionByZero for a zero x with a negative y and ErrOverflow if
+// the result does not fit. On error d is left unchanged.
+func (d *Dec) PowDecMode(x, y *Dec, scale uint8, mode RoundingMode) (*Dec, error) {
+	k := y.Key()
+	integral := k.scale == 0
+	if x.coef.Sign() < 0 && !integral {
+		return d, ErrDomain
+	}
+	// small integral exponents with exact results use PowerMode
+	c, s := bigInt(&x.coef), int(x.scale)
+	if integral && k.coef.Sign() >= 0 && k.coef.hi == 0 && k.coef.lo <= 127 {
+		n := int(k.coef.lo)
+		if new(big.Int).Abs(c).BitLen()*n <= 127 && s*n <= 18 {
+			var z Dec
+			z.PowerMode(x, n, mode)
+			return d.setFixed(bigInt(&z.coef), int(z.scale), scale, mode)
+		}
+	}
+	if y.coef.Sign() == 0 {
+		return d.Quantize(decOne, scale, mode), nil
+	}
+	if x.coef.Sign() == 0 {
+		if y.coef.Sign() < 0 {
+			return d, ErrDivisionByZero
+		}
+		return d.Quantize(&Dec{}, scale, mode), nil
+	}
+	// x**y = (-1)**y * exp(y * ln|x|)
+	neg := x.coef.Sign() < 0 && k.coef.lo&1 == 1
+	c.Abs(c)
+	yc, ys := bigInt(&y.coef), int(y.scale)
+	// estimate y * ln|x| * 10**20 to bound the result
+	t := lnFixed(c, s, 20)
+	t.Mul(t, yc)
+	t.Quo(t, pow10big(ys))
+	if t.Cmp(new(big.Int).Mul(big.NewInt(89), pow10big(20))) > 0 {
+		return d, ErrOverflow
+	}
+	// the result is less than 10**-(scale+2) in magnitude
+	if t.Cmp(new(big.Int).Mul(big.NewInt(-231*(int64(scale)+2)), pow10big(18))) < 0 {
+		d.coef = Int128{}
+		if mode.away(neg, false, -1, false) {
+			d.coef.lo = 1
+			if neg {
+				d.coef.Neg(&d.coef)
+			}
+		}
+		d.scale = scale
+		return d, nil
+	}
+	// the error of the logarithm is multiplied by y
+	ydig := len(new(big.Int).Abs(yc).String())
+	return d.setApprox(func(p int) *big.Int {
+		q := p + guard + ydig
+		a := lnFixed(c, s, q)
+		a.Mul(a, yc)
+		r := roundBig(expFixed(a, q+ys, p+guard), guard, RoundHalfEven)
+		if neg {
+			r.Neg(r)
+		}
+		return r
+	}, !neg, scale, mode)
+}
+
+// PowerScale sets d to x**n correctly rounded to the given scale according
+// to mode and returns d. ErrDivisionByZero is returned for a zero x with a
+// negative n and ErrOverflow if the result does not fit. On error d is left
+// unchanged.
+func (d *Dec) PowerScale(x *Dec, n int, scale uint8, mode RoundingMode) (*Dec, error) {
+	c := bigInt(&x.coef)
+	k := n
+	if k < 0 {
+		k = -k
+	}
+	if c.BitLen()*k > 1<<16 {
+		// too large to compute exactly
+		return d.PowDecMode(x, New(int64(n)), scale, mode)
+	}
+	s := int(x.scale) * k
+	c.Exp(c, big.NewInt(int64(k)), nil)
+	if n >= 0 {
+		return d.setFixed(c, s, scale, mode)
+	}
+	if c.Sign() == 0 {
+		return d, ErrDivisionByZero
+	}
+	// x**n = 10**s / c**-n
+	neg := c.Sign() < 0
+	c.Abs(c)
+	v := pow10big(s + int(scale))
+	if neg {
+		v.Neg(v)
+	}
+	return d.setFixed(quoBig(v, c, mode), int(scale), scale, mode)
+}
+
+// powerNeg sets d to x**n for a negative n, correctly rounded according
+// to mode to the scale of x plus -n or to scale 18 if that is larger,
+// reduced so that the result fits. An exact result has its trailing zeros
+// removed down to the scale of x plus -n. On error d is left unchanged.
+func (d *Dec) powerNeg(x *Dec, n int, mode RoundingMode) error {
+	if x.coef.Sign() == 0 {
+		return ErrDivisionByZero
+	}
+	ideal := int(x.scale) - n
+	scale := ideal
+	if scale < 18 {
+		scale = 18
+	}
+	// the result is about 10**e and has at most MaxPrecision digits
+	e := float64(n) * math.Log10(math.Abs(x.Float64()))
+	if m := MaxPrecision - 1 - int(math.Floor(e)); m < scale {
+		scale = m
+	}
+	if scale > 255 {
+		scale = 255
+	} else if scale < 0 {
+		scale = 0
+	}
+	// the estimate of the digits may be one short
+	for ; scale >= 0; scale-- {
+		if err := d.powerNegScale(x, n, ideal, scale, mode); err != ErrOverflow {
+			return err
+		}
+	}
+	return ErrOverflow
+}
+
+func (d *Dec) powerNegScale(x *Dec, n, ideal, scale int, mode RoundingMode) error {
+	c := bigInt(&x.coef)
+	if c.BitLen()*-n > 1<<16 {
+		// too large to compute exactly
+		_, err := d.PowDecMode(x, New(int64(n)), uint8(scale), mode)
+		return err
+	}
+	// x**n = 10**(s*-n) / c**-n
+	neg := c.Sign() < 0 && n&1 == 1
+	c.Abs(c)
+	c.Exp(c, big.NewInt(int64(-n)), nil)
+	v := pow10big(int(x.scale)*-n + scale)
+	if neg {
+		v.Neg(v)
+	}
+	q, r := new(big.Int).QuoRem(v, c, new(big.Int))
+	if r.Sign() != 0 {
+		q = quoBig(v, c, mode)
+	} else {
+		ten := big.NewInt(10)
+		for ; scale > ideal; scale-- {
+			t, m := new(big.Int).QuoRem(q, ten, new(big.Int))
+			if m.Sign() != 0 {
+				break
+			}
+			q = t
+		}
+	}
+	_, err := d.setFixed(q, scale, uint8(scale), mode)
+	return err
+}
+
+// PowDec sets d to x**y and returns d.
+// The resulting value is rounded half up to the given scale.
+func (d *NullDec) PowDec(x, y *NullDec, scale uint8) (*NullDec, error) {
+	return d.PowDecMode(x, y, scale, RoundHalfUp)
+}
+
+// PowDecMode sets d to x**y rounded to the given scale according to mode
+// and returns d.
+func (d *NullDec) PowDecMode(x, y *NullDec, scale uint8, mode RoundingMode) (*NullDec, error) {
+	if x.Null() || y.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.PowDecMode(&x.dec, &y.dec, scale, mode); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
+
+// PowerScale sets d to x**n correctly rounded to the given scale according
+// to mode and returns d.
+func (d *NullDec) PowerScale(x *NullDec, n int, scale uint8, mode RoundingMode) (*NullDec, error) {
+	if x.Null() {
+		return d.SetNull(), nil
+	}
+	if _, err := d.dec.PowerScale(&x.dec, n, scale, mode); err != nil {
+		return d, err
+	}
+	d.valid = true
+	return d, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func TestPowDec(t *testing.T) {
+	values := []struct {
+		x, y  string
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"1.05", "0.0833333333333333", 10, RoundHalfUp, "1.0040741238"},
+		{"2", "0.5", 37, RoundHalfEven, "1.4142135623730950488016887242096980786"},
+		{"16", "0.25", 5, RoundDown, "2.00000"},
+		{"1.1", "12", 20, RoundHalfUp, "3.13842837672100000000"},
+		{"-2", "3", 2, RoundHalfUp, "-8.00"},
+		{"-2", "-3", 4, RoundHalfUp, "-0.1250"},
+		{"-1.5", "7", 3, RoundFloor, "-17.086"},
+		{"10", "-2", 4, RoundHalfUp, "0.0100"},
+		{"0.5", "100", 20, RoundHalfUp, "0.00000000000000000000"},
+		{"1.0001", "100000", 10, RoundHalfUp, "22015.4560485522"},
+		{"3", "2.5", 15, RoundUp, "15.588457268119896"},
+		{"100", "-0.5", 3, RoundHalfUp, "0.100"},
+		{"1.5", "200", 0, RoundHalfUp, "165291991078820803015600259355571011"},
+		{"-0.5", "333", 30, RoundHalfUp, "0.000000000000000000000000000000"},
+		{"-0.5", "333", 3, RoundUp, "-0.001"},
+		{"1.01", "1000", 12, RoundCeiling, "20959.155637813661"},
+		{"7", "0.3333333333333333333333333333333333", 30, RoundHalfUp, "1.912931182772389101199116839549"},
+		{"2", "4.00", 1, RoundHalfUp, "16.0"},
+		{"5", "0", 2, RoundHalfUp, "1.00"},
+		{"0", "0.5", 2, RoundHalfUp, "0.00"},
+		{"0", "0", 0, RoundHalfUp, "1"},
+	}
+	for _, a := range values {
+		var z Dec
+		if _, err := z.PowDecMode(parse(a.x), parse(a.y), a.scale, a.mode); err != nil || z.String() != a.r {
+			t.Errorf("%s ** %s scale %d %s got %s %v want %s", a.x, a.y, a.scale, a.mode, z, err, a.r)
+		}
+	}
+	fails := []struct {
+		x, y string
+		err  error
+	}{
+		{"-2", "0.5", ErrDomain},
+		{"0", "-1", ErrDivisionByZero},
+		{"10", "39", ErrOverflow},
+		{"2", "127.5", ErrOverflow},
+		{"1.5", "219", ErrOverflow},
+	}
+	for _, a := range fails {
+		z := New(7)
+		if _, err := z.PowDec(parse(a.x), parse(a.y), 2); err != a.err || z.String() != "7" {
+			t.Errorf("%s ** %s got %s %v want %v", a.x, a.y, z, err, a.err)
+		}
+	}
+}
+
+func TestPowerScale(t *testing.T) {
+	values := []struct {
+		x     string
+		n     int
+		scale uint8
+		mode  RoundingMode
+		r     string
+	}{
+		{"1.005", 360, 10, RoundHalfUp, "6.0225752123"},
+		{"1.0041666666666667", 360, 20, RoundHalfEven, "4.46774431400618560290"},
+		{"2", -3, 2, RoundHalfUp, "0.13"},
+		{"-2", -3, 4, RoundHalfUp, "-0.1250"},
+		{"-1.5", 7, 3, RoundFloor, "-17.086"},
+		{"0.3", -5, 10, RoundDown, "411.5226337448"},
+		{"1.1", 100, 30, RoundHalfUp, "13780.612339822270184118337172089637"},
+		{"1.000001", 1000000, 20, RoundHalfUp, "2.71828046931937688382"},
+		{"0.999999", 2000000, 30, RoundHalfUp, "0.135335147901306899400768169660"},
+		{"3", 0, 2, RoundHalfUp, "1.00"},
+		{"-3", -1, 5, RoundCeiling, "-0.33333"},
+		{"0.0000001", 5, 40, RoundHalfUp, "0.0000000000000000000000000000000000100000"},
+		{"0", 3, 1, RoundHalfUp, "0.0"},
+	}
+	for _, a := range values {
+		var z Dec
+		if _, err := z.PowerScale(parse(a.x), a.n, a.scale, a.mode); err != nil || z.String() != a.r {
+			t.Errorf("%s ** %d scale %d %s got %s %v want %s", a.x, a.n, a.scale, a.mode, z, err, a.r)
+		}
+	}
+	fails := []struct {
+		x   string
+		n   int
+		err error
+	}{
+		{"0", -1, ErrDivisionByZero},
+		{"10", 39, ErrOverflow},
+		{"0.1", -39, ErrOverflow},
+		{"1.0001", 10000000, ErrOverflow},
+	}
+	for _, a := range fails {
+		z := New(7)
+		if _, err := z.PowerScale(parse(a.x), a.n, 2, RoundHalfUp); err != a.err || z.String() != "7" {
+			t.Errorf("%s ** %d got %s %v want %v", a.x, a.n, z, err, a.err)
+		}
+	}
+}
+
+func TestNullPowerScale(t *testing.T) {
+	values := []struct {
+		x string
+		r string
+	}{
+		{"1.1", "1.21"},
+		{"", ""},
+	}
+	for _, a := range values {
+		var x, z NullDec
+		x.SetString(a.x)
+		if _, err := z.PowerScale(&x, 2, 2, RoundHalfUp); err != nil || z.String() != a.r {
+			t.Errorf("%s ** 2 got %s %v want %s", a.x, z, err, a.r)
+		}
+	}
+}
+
+func TestNullPowDec(t *testing.T) {
+	values := []struct {
+		x, y string
+		r    string
+	}{
+		{"1.21", "0.5", "1.10"},
+		{"", "0.5", ""},
+		{"2", "", ""},
+	}
+	for _, a := range values {
+		var x, y, z NullDec
+		x.SetString(a.x)
+		y.SetString(a.y)
+		if _, err := z.PowDec(&x, &y, 2); err != nil || z.String() != a.r {
+			t.Errorf("%s ** %s got %s %v want %s", a.x, a.y, z, err, a.r)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "strconv"
+
+// DivisionMode determines how the integer quotient of a division is
+// rounded and so the sign of the remainder. The zero value is Truncated.
+type DivisionMode uint8
+
+const (
+	Truncated DivisionMode = iota // quotient toward zero, remainder has the sign of x
+	Floored                       // quotient toward -∞, remainder has the sign of y
+	Euclidean                     // remainder is never negative
+)
+
+var divisionModeNames = [...]string{
+	Truncated: "Truncated",
+	Floored:   "Floored",
+	Euclidean: "Euclidean",
+}
+
+// String returns the name of mode.
+func (mode DivisionMode) String() string {
+	if int(mode) < len(divisionModeNames) {
+		return divisionModeNames[mode]
+	}
+	return "DivisionMode(" + strconv.Itoa(int(mode)) + ")"
+}
+
+// QuoRem sets d to the integer quotient x/y and r to the remainder
+// x - d*y according to mode and returns the pair (d, r).
+// The scale of d is zero and the scale of r is the larger of the scales
+// of the two operands.
+// If y is zero panics with Division by zero.
+func (d *Dec) QuoRem(x, y, r *Dec, mode DivisionMode) (*Dec, *Dec) {
+	if y.coef.Sign() == 0 {
+		panic("Division by zero")
+	}
+	var my, q, m uint256
+	var scale uint8
+	large := false
+	if dx, dy, err := maxscaleChecked(x, y); err == nil {
+		var qi, ri Int128
+		qi.DivMod(&dx.coef, &dy.coef, &ri)
+		q.setInt128(&qi)
+		m.setInt128(&ri)
+		my.setInt128(&dy.coef)
+		scale = dx.scale
+	} else {
+		// align the operands in 256 bits
+		var mx uint256
+		mx.setInt128(&x.coef)
+		my.setInt128(&y.coef)
+		scale = x.scale
+		if k := int(y.scale) - int(x.scale); k > 0 {
+			scale = y.scale
+			if k >= len(pow10w) || !mx.mul(&mx, &pow10w[k]) {
+				overflow()
+			}
+		} else {
+			// an y that cannot be aligned exceeds x
+			large = -k >= len(pow10w) || !my.mul(&my, &pow10w[-k])
+		}
+		if large {
+			m = mx
+		} else {
+			q.divmod(&mx, &my, &m)
+		}
+	}
+	xneg := x.coef.Sign() < 0
+	qneg := xneg != (y.coef.Sign() < 0)
+	rneg := xneg
+	if !m.isZero() && (mode == Floored && qneg || mode == Euclidean && xneg) {
+		// move the quotient one away from zero and the remainder
+		// to the other side of zero
+		if large {
+			overflow()
+		}
+		q.add(&q, &uint256{1})
+		m.sub(&my, &m)
+		rneg = !xneg
+	}
+	qc, ok := q.int128(qneg)
+	if !ok {
+		overflow()
+	}
+	rc, ok := m.int128(rneg)
+	if !ok {
+		overflow()
+	}
+	d.coef = qc
+	d.scale = 0
+	r.coef = rc
+	r.scale = scale
+	return d, r
+}
+
+// QuoInt sets d to the integer quotient x/y according to mode and
+// returns d. The scale of d is zero.
+// If y is zero panics with Division by zero.
+func (d *Dec) QuoInt(x, y *Dec, mode DivisionMode) *Dec {
+	var r Dec
+	d.QuoRem(x, y, &r, mode)
+	return d
+}
+
+// Mod sets d to the remainder x - y*QuoInt(x, y) according to mode and
+// returns d. The scale of d is the larger of the scales of the two operands.
+// If y is zero panics with Division by zero.
+func (d *Dec) Mod(x, y *Dec, mode DivisionMode) *Dec {
+	var q Dec
+	q.QuoRem(x, y, d, mode)
+	return d
+}
+
+// Rem sets d to the truncated remainder of x/y, that has the sign of x,
+// and returns d. The scale of d is the larger of the scales of the two
+// operands.
+// If y is zero panics with Division by zero.
+func (d *Dec) Rem(x, y *Dec) *Dec {
+	return d.Mod(x, y, Truncated)
+}
+
+// QuoRem sets d to the integer quotient x/y and r to the remainder
+// x - d*y according to mode and returns the pair (d, r).
+func (d *NullDec) QuoRem(x, y, r *NullDec, mode DivisionMode) (*NullDec, *NullDec) {
+	if x.Null() || y.Null() {
+		d.SetNull()
+		r.SetNull()
+	} else {
+		d.dec.QuoRem(&x.dec, &y.dec, &r.dec, mode)
+		d.valid = true
+		r.valid = true
+	}
+	return d, r
+}
+
+// QuoInt sets d to the integer quotient x/y according to mode and
+// returns d.
+func (d *NullDec) QuoInt(x, y *NullDec, mode DivisionMode) *NullDec {
+	if x.Null() || y.Null() {
+		d.SetNull()
+	} else {
+		d.dec.QuoInt(&x.dec, &y.dec, mode)
+		d.valid = true
+	}
+	return d
+}
+
+// Mod sets d to the remainder x - y*QuoInt(x, y) according to mode and
+// returns d.
+func (d *NullDec) Mod(x, y *NullDec, mode DivisionMode) *NullDec {
+	if x.Null() || y.Null() {
+		d.SetNull()
+	} else {
+		d.dec.Mod(&x.dec, &y.dec, mode)
+		d.valid = true
+	}
+	return d
+}
+
+// Rem sets d to the truncated remainder of x/y and returns d.
+func (d *NullDec) Rem(x, y *NullDec) *NullDec {
+	return d.Mod(x, y, Truncated)
+}
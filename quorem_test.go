@@ -0,0 +1,112 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func TestQuoRem(t *testing.T) {
+	modes := []DivisionMode{Truncated, Floored, Euclidean}
+	values := []struct {
+		x, y string
+		q, r [3]string
+	}{
+		{"10.30", "0.25", [3]string{"41", "41", "41"}, [3]string{"0.05", "0.05", "0.05"}},
+		{"7", "2", [3]string{"3", "3", "3"}, [3]string{"1", "1", "1"}},
+		{"-7", "2", [3]string{"-3", "-4", "-4"}, [3]string{"-1", "1", "1"}},
+		{"7", "-2", [3]string{"-3", "-4", "-3"}, [3]string{"1", "-1", "1"}},
+		{"-7", "-2", [3]string{"3", "3", "4"}, [3]string{"-1", "-1", "1"}},
+		{"6", "-3", [3]string{"-2", "-2", "-2"}, [3]string{"0", "0", "0"}},
+		{"1.5", "0.7", [3]string{"2", "2", "2"}, [3]string{"0.1", "0.1", "0.1"}},
+		{"-1", "10", [3]string{"0", "-1", "-1"}, [3]string{"-1", "9", "9"}},
+		{"0", "-3.0", [3]string{"0", "0", "0"}, [3]string{"0.0", "0.0", "0.0"}},
+		{"1000000000000000000000000000000", "100000000000000000000.000000000000000000",
+			[3]string{"10000000000", "10000000000", "10000000000"},
+			[3]string{"0.000000000000000000", "0.000000000000000000", "0.000000000000000000"}},
+		{"0.00000000000000000001", "1000000000000000000000000000000",
+			[3]string{"0", "0", "0"},
+			[3]string{"0.00000000000000000001", "0.00000000000000000001", "0.00000000000000000001"}},
+		{"0.00000000000000000000000000000000000000000000000001", "100000000000000000000000000000000000000",
+			[3]string{"0", "0", "0"},
+			[3]string{"0.00000000000000000000000000000000000000000000000001",
+				"0.00000000000000000000000000000000000000000000000001",
+				"0.00000000000000000000000000000000000000000000000001"}},
+	}
+	for _, a := range values {
+		for i, mode := range modes {
+			var q, r Dec
+			q.QuoRem(parse(a.x), parse(a.y), &r, mode)
+			if q.String() != a.q[i] || r.String() != a.r[i] {
+				t.Errorf("%s / %s %s got %s %s want %s %s", a.x, a.y, mode, q, r, a.q[i], a.r[i])
+			}
+			q.QuoInt(parse(a.x), parse(a.y), mode)
+			r.Mod(parse(a.x), parse(a.y), mode)
+			if q.String() != a.q[i] || r.String() != a.r[i] {
+				t.Errorf("quoint mod %s / %s %s got %s %s want %s %s", a.x, a.y, mode, q, r, a.q[i], a.r[i])
+			}
+		}
+		var r Dec
+		if r.Rem(parse(a.x), parse(a.y)); r.String() != a.r[0] {
+			t.Errorf("rem %s / %s got %s want %s", a.x, a.y, r, a.r[0])
+		}
+	}
+	// aliased operands
+	x := parse("10.30")
+	y := parse("0.25")
+	x.QuoRem(x, y, y, Truncated)
+	if x.String() != "41" || y.String() != "0.05" {
+		t.Errorf("aliased quorem got %s %s want 41 0.05", x, y)
+	}
+	panicking := []struct {
+		x, y string
+		mode DivisionMode
+		msg  string
+	}{
+		{"1", "0", Truncated, "Division by zero"},
+		{"100000000000000000000000000000000000000", "0.00000000000000000000000000000000000001", Truncated, "Arithmetic overflow"},
+		{"-0.00000000000000000000000000000000000000000000000001", "100000000000000000000000000000000000000", Floored, "Arithmetic overflow"},
+	}
+	for _, a := range panicking {
+		if a.msg != panics(func() {
+			var q, r Dec
+			q.QuoRem(parse(a.x), parse(a.y), &r, a.mode)
+		}) {
+			t.Errorf("%s / %s %s failed to panic with %s", a.x, a.y, a.mode, a.msg)
+		}
+	}
+	if Euclidean.String() != "Euclidean" || DivisionMode(9).String() != "DivisionMode(9)" {
+		t.Errorf("division mode names")
+	}
+}
+
+func TestNullQuoRem(t *testing.T) {
+	values := []struct {
+		x, y string
+		q, r string
+	}{
+		{"-7", "2", "-4", "1"},
+		{"", "2", "", ""},
+		{"7", "", "", ""},
+	}
+	for _, a := range values {
+		var x, y, q, r NullDec
+		x.SetString(a.x)
+		y.SetString(a.y)
+		q.QuoRem(&x, &y, &r, Floored)
+		if q.String() != a.q || r.String() != a.r {
+			t.Errorf("%s / %s got %s %s want %s %s", a.x, a.y, q, r, a.q, a.r)
+		}
+		q.QuoInt(&x, &y, Floored)
+		r.Mod(&x, &y, Floored)
+		if q.String() != a.q || r.String() != a.r {
+			t.Errorf("quoint mod %s / %s got %s %s want %s %s", a.x, a.y, q, r, a.q, a.r)
+		}
+	}
+	var x, y, r NullDec
+	x.SetString("-7")
+	y.SetString("2")
+	if r.Rem(&x, &y); r.String() != "-1" {
+		t.Errorf("rem -7 / 2 got %s want -1", r)
+	}
+}
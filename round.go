@@ -0,0 +1,66 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "strconv"
+
+// RoundingMode determines how a value is rounded when digits are discarded.
+// The zero value is RoundHalfUp.
+type RoundingMode uint8
+
+const (
+	RoundHalfUp   RoundingMode = iota // round to nearest, ties away from zero
+	RoundHalfEven                     // round to nearest, ties to even (banker's rounding)
+	RoundHalfDown                     // round to nearest, ties toward zero
+	RoundDown                         // truncate toward zero
+	RoundUp                           // round away from zero
+	RoundCeiling                      // round toward +∞
+	RoundFloor                        // round toward -∞
+)
+
+var roundingModeNames = [...]string{
+	RoundHalfUp:   "HalfUp",
+	RoundHalfEven: "HalfEven",
+	RoundHalfDown: "HalfDown",
+	RoundDown:     "Down",
+	RoundUp:       "Up",
+	RoundCeiling:  "Ceiling",
+	RoundFloor:    "Floor",
+}
+
+// String returns the name of mode.
+func (mode RoundingMode) String() string {
+	if int(mode) < len(roundingModeNames) {
+		return roundingModeNames[mode]
+	}
+	return "RoundingMode(" + strconv.Itoa(int(mode)) + ")"
+}
+
+// away reports whether a truncated result must be incremented in magnitude.
+// neg is the sign of the exact result, odd is the parity of the truncated
+// result, half compares the discarded fraction with one half (-1, 0, +1)
+// and exact is true when nothing was discarded.
+func (mode RoundingMode) away(neg, odd bool, half int, exact bool) bool {
+	if exact {
+		return false
+	}
+	switch mode {
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfEven:
+		return half > 0 || (half == 0 && odd)
+	case RoundHalfDown:
+		return half > 0
+	case RoundDown:
+		return false
+	case RoundUp:
+		return true
+	case RoundCeiling:
+		return !neg
+	case RoundFloor:
+		return neg
+	}
+	panic("invalid rounding mode")
+}
@@ -0,0 +1,55 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func TestRoundingModes(t *testing.T) {
+	modes := []RoundingMode{
+		RoundHalfUp,
+		RoundHalfEven,
+		RoundHalfDown,
+		RoundDown,
+		RoundUp,
+		RoundCeiling,
+		RoundFloor,
+	}
+	values := []struct {
+		x string
+		r [7]string
+	}{
+		{"5.5", [7]string{"6", "6", "5", "5", "6", "6", "5"}},
+		{"2.5", [7]string{"3", "2", "2", "2", "3", "3", "2"}},
+		{"1.6", [7]string{"2", "2", "2", "1", "2", "2", "1"}},
+		{"1.1", [7]string{"1", "1", "1", "1", "2", "2", "1"}},
+		{"1.0", [7]string{"1", "1", "1", "1", "1", "1", "1"}},
+		{"-1.0", [7]string{"-1", "-1", "-1", "-1", "-1", "-1", "-1"}},
+		{"-1.1", [7]string{"-1", "-1", "-1", "-1", "-2", "-1", "-2"}},
+		{"-1.6", [7]string{"-2", "-2", "-2", "-1", "-2", "-1", "-2"}},
+		{"-2.5", [7]string{"-3", "-2", "-2", "-2", "-3", "-2", "-3"}},
+		{"-5.5", [7]string{"-6", "-6", "-5", "-5", "-6", "-5", "-6"}},
+		{"0.4", [7]string{"0", "0", "0", "0", "1", "1", "0"}},
+		{"-0.4", [7]string{"0", "0", "0", "0", "-1", "0", "-1"}},
+	}
+	for _, a := range values {
+		for i, mode := range modes {
+			var x Dec
+			x.SetString(a.x)
+			x.RoundMode(0, mode)
+			if x.String() != a.r[i] {
+				t.Errorf("round %s %s got %s want %s", a.x, mode, x, a.r[i])
+			}
+		}
+	}
+}
+
+func TestRoundingModeString(t *testing.T) {
+	if s := RoundHalfEven.String(); s != "HalfEven" {
+		t.Errorf("got %s want HalfEven", s)
+	}
+	if s := RoundingMode(42).String(); s != "RoundingMode(42)" {
+		t.Errorf("got %s want RoundingMode(42)", s)
+	}
+}
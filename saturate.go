@@ -0,0 +1,112 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "math"
+
+// The saturating methods clamp a result that overflows to the largest or
+// smallest value, ±(2**127-1), and report whether saturation happened.
+
+// saturate sets z to the largest value if neg is false,
+// to the smallest otherwise, and returns z.
+func (z *Int128) saturate(neg bool) *Int128 {
+	z.lo = math.MaxUint64
+	z.hi = math.MaxInt64
+	if neg {
+		z.Neg(z)
+	}
+	return z
+}
+
+// isMin reports whether x is -2**127, which is outside the saturation range.
+func (x *Int128) isMin() bool {
+	return x.hi == math.MinInt64 && x.lo == 0
+}
+
+// AddSat sets z to the sum x+y clamped to the range of Int128 and returns z
+// and whether it was clamped.
+func (z *Int128) AddSat(x, y *Int128) (*Int128, bool) {
+	neg := x.hi < 0
+	if !z.add(x, y) {
+		return z.saturate(neg), true
+	}
+	if z.isMin() {
+		// -2**127 is below the range without an overflow
+		return z.saturate(true), true
+	}
+	return z, false
+}
+
+// SubSat sets z to the difference x-y clamped to the range of Int128
+// and returns z and whether it was clamped.
+func (z *Int128) SubSat(x, y *Int128) (*Int128, bool) {
+	neg := x.hi < 0
+	if !z.sub(x, y) {
+		return z.saturate(neg), true
+	}
+	if z.isMin() {
+		// -2**127 is below the range without an overflow
+		return z.saturate(true), true
+	}
+	return z, false
+}
+
+// MulSat sets z to the product x*y clamped to the range of Int128
+// and returns z and whether it was clamped.
+func (z *Int128) MulSat(x, y *Int128) (*Int128, bool) {
+	neg := (x.hi < 0) != (y.hi < 0)
+	if z.mul(x, y) && !z.isMin() {
+		return z, false
+	}
+	return z.saturate(neg), true
+}
+
+// setSat sets d to (-1)**neg * m * 10**-scale at the given scale, clamping
+// the coefficient if it does not fit, and returns d and whether it was clamped.
+func (d *Dec) setSat(neg bool, m *uint256, scale int, target uint8) (*Dec, bool) {
+	d.scale = target
+	if scale == int(target) {
+		if coef, ok := m.int128(neg); ok {
+			d.coef = coef
+			return d, false
+		}
+	}
+	d.coef.saturate(neg)
+	return d, true
+}
+
+// AddSat sets d to the sum x+y and returns d and whether it was clamped.
+// The scale of d is the larger of the scales of the two operands and
+// the coefficient is clamped to the range of Int128.
+func (d *Dec) AddSat(x, y *Dec) (*Dec, bool) {
+	scale := maxScale(x, y)
+	neg, m, s := addWide(x, y, false)
+	return d.setSat(neg, &m, s, scale)
+}
+
+// SubSat sets d to the difference x-y and returns d and whether it was
+// clamped. The scale of d is the larger of the scales of the two operands
+// and the coefficient is clamped to the range of Int128.
+func (d *Dec) SubSat(x, y *Dec) (*Dec, bool) {
+	scale := maxScale(x, y)
+	neg, m, s := addWide(x, y, true)
+	return d.setSat(neg, &m, s, scale)
+}
+
+// MulSat sets d to the product x*y and returns d and whether it was
+// clamped. The scale of d is the sum of the scales of the two operands
+// and the coefficient is clamped to the range of Int128.
+func (d *Dec) MulSat(x, y *Dec) (*Dec, bool) {
+	d.scale = x.scale + y.scale
+	_, sat := d.coef.MulSat(&x.coef, &y.coef)
+	return d, sat
+}
+
+func maxScale(x, y *Dec) uint8 {
+	if x.scale > y.scale {
+		return x.scale
+	}
+	return y.scale
+}
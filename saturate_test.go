@@ -0,0 +1,87 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntSat(t *testing.T) {
+	max := Int128{math.MaxUint64, math.MaxInt64}
+	var min Int128
+	min.Neg(&max)
+	least := Int128{0, math.MinInt64} // -2**127
+	values := []struct {
+		op   string
+		x, y Int128
+		z    Int128
+		sat  bool
+	}{
+		{"+", max, *intOne, max, true},
+		{"+", min, Int128{math.MaxUint64, -1}, min, true},
+		{"+", max, Int128{math.MaxUint64, -1}, Int128{math.MaxUint64 - 1, math.MaxInt64}, false},
+		{"+", Int128{}, least, min, true},
+		{"+", least, Int128{}, min, true},
+		{"-", min, *intOne, min, true},
+		{"-", max, Int128{math.MaxUint64, -1}, max, true},
+		{"-", Int128{math.MaxUint64, -1}, max, min, true},
+		{"-", *intTen, *intOne, Int128{9, 0}, false},
+		{"*", max, *intTen, max, true},
+		{"*", min, *intTen, min, true},
+		{"*", max, Int128{math.MaxUint64, -1}, min, false},
+		{"*", *intTen, *intTen, Int128{100, 0}, false},
+	}
+	for _, a := range values {
+		var z Int128
+		var sat bool
+		switch a.op {
+		case "+":
+			_, sat = z.AddSat(&a.x, &a.y)
+		case "-":
+			_, sat = z.SubSat(&a.x, &a.y)
+		case "*":
+			_, sat = z.MulSat(&a.x, &a.y)
+		}
+		if z.Cmp(&a.z) != 0 || sat != a.sat {
+			t.Errorf("%s %s %s got %s %v want %s %v", a.x, a.op, a.y, z, sat, a.z, a.sat)
+		}
+	}
+}
+
+func TestSat(t *testing.T) {
+	values := []struct {
+		op   string
+		x, y string
+		z    string
+		sat  bool
+	}{
+		{"+", "1.5", "0.25", "1.75", false},
+		{"+", "170141183460469231731687303715884105727", "1", "170141183460469231731687303715884105727", true},
+		{"+", "17014118346046923173168730371588410573", "0.1", "17014118346046923173168730371588410572.7", true},
+		{"+", "17014118346046923173168730371588410573", "-1000000000000000000000000000000000000.0", "16014118346046923173168730371588410573.0", false},
+		{"-", "-17014118346046923173168730371588410573", "0.1", "-17014118346046923173168730371588410572.7", true},
+		{"-", "1", "0.001", "0.999", false},
+		{"*", "10000000000000000000", "-100000000000000000000", "-170141183460469231731687303715884105727", true},
+		{"*", "1.5", "-1.5", "-2.25", false},
+	}
+	for _, a := range values {
+		var x, y, z Dec
+		x.SetString(a.x)
+		y.SetString(a.y)
+		var sat bool
+		switch a.op {
+		case "+":
+			_, sat = z.AddSat(&x, &y)
+		case "-":
+			_, sat = z.SubSat(&x, &y)
+		case "*":
+			_, sat = z.MulSat(&x, &y)
+		}
+		if z.String() != a.z || sat != a.sat {
+			t.Errorf("%s %s %s got %s %v want %s %v", a.x, a.op, a.y, z, sat, a.z, a.sat)
+		}
+	}
+}
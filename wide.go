@@ -0,0 +1,432 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"math/bits"
+)
+
+// uint256 is an unsigned 256 bit integer used for exact intermediate
+// results. The words are stored least significant first.
+type uint256 [4]uint64
+
+// pow10w holds the powers of ten that fit in an uint256.
+var pow10w = func() (t [78]uint256) {
+	t[0][0] = 1
+	for i := 1; i < len(t); i++ {
+		t[i].mulWord(&t[i-1], 10)
+	}
+	return
+}()
+
+// setInt128 sets z to |x| and returns z.
+func (z *uint256) setInt128(x *Int128) *uint256 {
+	var a Int128
+	a.Abs(x)
+	z[0] = a.lo
+	z[1] = uint64(a.hi)
+	z[2] = 0
+	z[3] = 0
+	return z
+}
+
+// int128 returns x with the given sign as an Int128
+// and reports whether it fits.
+func (x *uint256) int128(neg bool) (Int128, bool) {
+	if x[2] != 0 || x[3] != 0 || x[1]>>63 != 0 {
+		return Int128{}, false
+	}
+	z := Int128{x[0], int64(x[1])}
+	if neg {
+		z.Neg(&z)
+	}
+	return z, true
+}
+
+func (x *uint256) isZero() bool {
+	return x[0] == 0 && x[1] == 0 && x[2] == 0 && x[3] == 0
+}
+
+// len returns the number of significant words of x.
+func (x *uint256) len() int {
+	n := len(x)
+	for n > 0 && x[n-1] == 0 {
+		n--
+	}
+	return n
+}
+
+// digits returns the number of decimal digits of x, zero for x == 0.
+func (x *uint256) digits() int {
+	n := 0
+	for n < len(pow10w) && x.cmp(&pow10w[n]) >= 0 {
+		n++
+	}
+	return n
+}
+
+// cmp compares x and y and returns -1, 0 or +1.
+func (x *uint256) cmp(y *uint256) int {
+	for i := len(x) - 1; i >= 0; i-- {
+		if x[i] > y[i] {
+			return 1
+		} else if x[i] < y[i] {
+			return -1
+		}
+	}
+	return 0
+}
+
+// add sets z to x+y and reports whether the sum fits.
+func (z *uint256) add(x, y *uint256) bool {
+	var c uint64
+	for i := range z {
+		z[i], c = bits.Add64(x[i], y[i], c)
+	}
+	return c == 0
+}
+
+// sub sets z to x-y for x >= y.
+func (z *uint256) sub(x, y *uint256) {
+	var b uint64
+	for i := range z {
+		z[i], b = bits.Sub64(x[i], y[i], b)
+	}
+}
+
+// mulWord sets z to x*w and reports whether the product fits.
+func (z *uint256) mulWord(x *uint256, w uint64) bool {
+	var carry uint64
+	for i := range z {
+		hi, lo := bits.Mul64(x[i], w)
+		var c uint64
+		z[i], c = bits.Add64(lo, carry, 0)
+		carry = hi + c
+	}
+	return carry == 0
+}
+
+// mul sets z to x*y and reports whether the product fits.
+// Algorithm M from Knuth TAOCP Vol 2 4.3.1
+func (z *uint256) mul(x, y *uint256) bool {
+	var w [8]uint64
+	for j := 0; j < len(y); j++ {
+		if y[j] == 0 {
+			continue
+		}
+		var k uint64
+		for i := 0; i < len(x); i++ {
+			hi, lo := bits.Mul64(x[i], y[j])
+			var c uint64
+			lo, c = bits.Add64(lo, w[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, k, 0)
+			hi += c
+			w[i+j] = lo
+			k = hi
+		}
+		w[j+len(x)] = k
+	}
+	copy(z[:], w[:4])
+	return w[4] == 0 && w[5] == 0 && w[6] == 0 && w[7] == 0
+}
+
+// divmod sets z to the quotient x/y and r to the remainder x%y.
+// If y == 0, a division-by-zero run-time panic occurs.
+// Knuth TAOCP 4.3.1 algorithm D
+func (z *uint256) divmod(x, y, r *uint256) {
+	n := y.len()
+	m := x.len()
+	if n == 0 {
+		panic("Division by zero")
+	}
+	if x.cmp(y) < 0 {
+		*r = *x
+		*z = uint256{}
+		return
+	}
+	var q uint256
+	if n == 1 {
+		var rem uint64
+		for i := m - 1; i >= 0; i-- {
+			q[i], rem = bits.Div64(rem, x[i], y[0])
+		}
+		*z = q
+		*r = uint256{rem}
+		return
+	}
+
+	// D1. Normalize.
+	var v [4]uint64
+	var u [5]uint64
+	s := uint(bits.LeadingZeros64(y[n-1]))
+	for i := n - 1; i > 0; i-- {
+		v[i] = y[i]<<s | y[i-1]>>(64-s)
+	}
+	v[0] = y[0] << s
+	u[m] = x[m-1] >> (64 - s)
+	for i := m - 1; i > 0; i-- {
+		u[i] = x[i]<<s | x[i-1]>>(64-s)
+	}
+	u[0] = x[0] << s
+
+	// D2. Initialize j. D7. Loop on j.
+	for j := m - n; j >= 0; j-- {
+		// D3. Calculate qhat.
+		var qhat, rhat, c uint64
+		if u[j+n] >= v[n-1] {
+			qhat = ^uint64(0)
+			rhat, c = bits.Add64(u[j+n-1], v[n-1], 0)
+		} else {
+			qhat, rhat = bits.Div64(u[j+n], u[j+n-1], v[n-1])
+		}
+		for c == 0 {
+			hi, lo := bits.Mul64(qhat, v[n-2])
+			if hi < rhat || (hi == rhat && lo <= u[j+n-2]) {
+				break
+			}
+			qhat--
+			rhat, c = bits.Add64(rhat, v[n-1], 0)
+		}
+		// D4. Multiply and subtract.
+		var k, b uint64
+		for i := 0; i < n; i++ {
+			hi, lo := bits.Mul64(qhat, v[i])
+			lo, c = bits.Add64(lo, k, 0)
+			k = hi + c
+			u[i+j], b = bits.Sub64(u[i+j], lo, b)
+		}
+		u[j+n], b = bits.Sub64(u[j+n], k, b)
+		// D5. Test remainder.
+		if b != 0 {
+			// D6. Add back.
+			qhat--
+			c = 0
+			for i := 0; i < n; i++ {
+				u[i+j], c = bits.Add64(u[i+j], v[i], c)
+			}
+			u[j+n] += c
+		}
+		q[j] = qhat
+	}
+
+	// D8. Unnormalize.
+	var rr uint256
+	for i := 0; i < n-1; i++ {
+		rr[i] = u[i]>>s | u[i+1]<<(64-s)
+	}
+	rr[n-1] = u[n-1] >> s
+	*z = q
+	*r = rr
+}
+
+// quoRound sets z to the quotient x/y rounded to an integer according to
+// mode, where neg is the sign of the quotient, and reports whether the
+// quotient was inexact.
+func (z *uint256) quoRound(x, y *uint256, neg bool, mode RoundingMode) bool {
+	v := *y
+	var r, h uint256
+	z.divmod(x, &v, &r)
+	if r.isZero() {
+		return false
+	}
+	h.sub(&v, &r)
+	if mode.away(neg, z[0]&1 == 1, r.cmp(&h), false) {
+		z.add(z, &uint256{1})
+	}
+	return true
+}
+
+// quoPow10 sets z to x/10**n rounded according to mode, where neg is the
+// sign of the value, and reports whether the result was inexact.
+func (z *uint256) quoPow10(x *uint256, n int, neg bool, mode RoundingMode) bool {
+	if n == 0 {
+		*z = *x
+		return false
+	} else if n < len(pow10w) {
+		return z.quoRound(x, &pow10w[n], neg, mode)
+	}
+	// x < 10**77 is less than half of 10**n
+	if x.isZero() {
+		*z = uint256{}
+		return false
+	}
+	*z = uint256{}
+	if mode.away(neg, false, -1, false) {
+		z[0] = 1
+	}
+	return true
+}
+
+// jam marks the truncated value x as inexact by making its last digit
+// neither 0 nor 5, so that rounding away at least one more digit
+// gives the correctly rounded result.
+func (x *uint256) jam() {
+	var q, r uint256
+	q.divmod(x, &pow10w[1], &r)
+	if r[0] == 0 || r[0] == 5 {
+		x.add(x, &uint256{1})
+	}
+}
+
+// setWide sets d to (-1)**neg * m * 10**-scale rounded according to mode
+// to at most prec significant digits and a scale that fits in an uint8,
+// and returns the conditions raised. On Overflow d is left unchanged.
+func (d *Dec) setWide(neg bool, m *uint256, scale, prec int, mode RoundingMode) Condition {
+	var cond Condition
+	w := *m
+	drop := 0
+	if n := w.digits(); n > prec {
+		drop = n - prec
+	}
+	if scale-drop > math.MaxUint8 {
+		drop = scale - math.MaxUint8
+	}
+	if drop > 0 {
+		cond |= Rounded
+		if w.quoPow10(&w, drop, neg, mode) {
+			cond |= Inexact
+		}
+		scale -= drop
+		if w.digits() > prec && scale > 0 {
+			// rounding carried into a new digit
+			w.quoPow10(&w, 1, neg, mode)
+			scale--
+		}
+	}
+	if scale < 0 {
+		if !w.isZero() && (-scale >= len(pow10w) || !w.mul(&w, &pow10w[-scale])) {
+			return cond | Overflow
+		}
+		scale = 0
+	}
+	coef, ok := w.int128(neg)
+	if !ok {
+		return cond | Overflow
+	}
+	d.coef = coef
+	d.scale = uint8(scale)
+	return cond
+}
+
+// setScaled sets d to (-1)**neg * m * 10**-s rounded to the given scale
+// according to mode and reports whether the result fits.
+// If it does not fit d is left unchanged.
+func (d *Dec) setScaled(neg bool, m *uint256, s int, scale uint8, mode RoundingMode) bool {
+	w := *m
+	if k := int(scale) - s; k < 0 {
+		w.quoPow10(&w, -k, neg, mode)
+	} else if k > 0 && !w.isZero() && (k >= len(pow10w) || !w.mul(&w, &pow10w[k])) {
+		return false
+	}
+	coef, ok := w.int128(neg)
+	if !ok {
+		return false
+	}
+	d.coef = coef
+	d.scale = scale
+	return true
+}
+
+// addWide returns the sum x+y, or the difference x-y if sub is true,
+// as a sign, a magnitude and a scale. When the operands cannot be aligned
+// exactly, the smaller one is truncated and the sum is jammed.
+func addWide(x, y *Dec, sub bool) (neg bool, m uint256, scale int) {
+	var mx, my uint256
+	mx.setInt128(&x.coef)
+	my.setInt128(&y.coef)
+	xneg := x.coef.Sign() < 0
+	yneg := (y.coef.Sign() < 0) != sub
+	xs, ys := int(x.scale), int(y.scale)
+	if xs > ys {
+		mx, my = my, mx
+		xneg, yneg = yneg, xneg
+		xs, ys = ys, xs
+	}
+	k := ys - xs
+	var sticky bool
+	if !mx.isZero() {
+		if n := len(pow10w) - 1 - mx.digits(); k > n {
+			sticky = my.quoPow10(&my, k-n, false, RoundDown)
+			k = n
+		}
+		mx.mul(&mx, &pow10w[k])
+	}
+	scale = xs + k
+	if xneg == yneg {
+		m.add(&mx, &my)
+		neg = xneg
+	} else if mx.cmp(&my) >= 0 {
+		m.sub(&mx, &my)
+		neg = xneg
+	} else {
+		m.sub(&my, &mx)
+		neg = yneg
+	}
+	if sticky {
+		if yneg != neg {
+			// the discarded digits reduce the magnitude
+			m.sub(&m, &uint256{1})
+		}
+		m.jam()
+	}
+	return
+}
+
+// mulWide returns the exact product x*y as a sign, a magnitude and a scale.
+func mulWide(x, y *Dec) (neg bool, m uint256, scale int) {
+	var my uint256
+	m.setInt128(&x.coef)
+	my.setInt128(&y.coef)
+	m.mul(&m, &my)
+	neg = (x.coef.Sign() < 0) != (y.coef.Sign() < 0)
+	return neg, m, int(x.scale) + int(y.scale)
+}
+
+// bitLen returns the number of bits required to represent x.
+func (x *uint256) bitLen() int {
+	n := x.len()
+	if n == 0 {
+		return 0
+	}
+	return (n-1)*64 + bits.Len64(x[n-1])
+}
+
+// rsh1 sets z to x >> 1 and returns z.
+func (z *uint256) rsh1(x *uint256) *uint256 {
+	for i := 0; i < len(z)-1; i++ {
+		z[i] = x[i]>>1 | x[i+1]<<63
+	}
+	z[len(z)-1] = x[len(z)-1] >> 1
+	return z
+}
+
+// sqrt sets z to the integer square root of x, the largest integer such
+// that z*z <= x, sets r to x - z*z and returns z.
+func (z *uint256) sqrt(x, r *uint256) *uint256 {
+	if x.isZero() {
+		*z = uint256{}
+		*r = uint256{}
+		return z
+	}
+	// Newton's method starting from a power of two not less than the root
+	var s, q, t uint256
+	n := uint(x.bitLen()+1) / 2
+	s[n/64] = 1 << (n % 64)
+	for {
+		q.divmod(x, &s, &t)
+		q.add(&q, &s)
+		q.rsh1(&q)
+		if q.cmp(&s) >= 0 {
+			break
+		}
+		s = q
+	}
+	t.mul(&s, &s)
+	r.sub(x, &t)
+	*z = s
+	return z
+}
@@ -0,0 +1,79 @@
+// Copyright 2014 Dimitris Dinodimos. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func (x *uint256) big() *big.Int {
+	z := new(big.Int)
+	for i := len(x) - 1; i >= 0; i-- {
+		z.Lsh(z, 64)
+		z.Or(z, new(big.Int).SetUint64(x[i]))
+	}
+	return z
+}
+
+func randUint256(words int) uint256 {
+	var x uint256
+	for i := 0; i < words; i++ {
+		x[i] = rand.Uint64()
+	}
+	return x
+}
+
+func TestRandWideMul(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		x := randUint256(1 + rand.Intn(2))
+		y := randUint256(1 + rand.Intn(2))
+		var z uint256
+		if !z.mul(&x, &y) {
+			t.Fatalf("overflow %s * %s", x.big(), y.big())
+		}
+		want := new(big.Int).Mul(x.big(), y.big())
+		if z.big().Cmp(want) != 0 {
+			t.Errorf("%s * %s got %s want %s", x.big(), y.big(), z.big(), want)
+		}
+	}
+	x := randUint256(3)
+	if x.mul(&x, &x) {
+		t.Errorf("failed to overflow mul")
+	}
+}
+
+func TestRandWideDivMod(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		x := randUint256(1 + rand.Intn(4))
+		y := randUint256(1 + rand.Intn(4))
+		y[0] |= 1
+		if rand.Intn(2) == 0 {
+			// exercise the qhat == b-1 path
+			y[y.len()-1] = x[x.len()-1]
+		}
+		var q, r uint256
+		q.divmod(&x, &y, &r)
+		wq, wr := new(big.Int).QuoRem(x.big(), y.big(), new(big.Int))
+		if q.big().Cmp(wq) != 0 || r.big().Cmp(wr) != 0 {
+			t.Errorf("%s / %s got q=%s r=%s want q=%s r=%s",
+				x.big(), y.big(), q.big(), r.big(), wq, wr)
+		}
+	}
+}
+
+func TestWideDigits(t *testing.T) {
+	for n := 1; n < len(pow10w); n++ {
+		if d := pow10w[n].digits(); d != n+1 {
+			t.Errorf("digits of 10**%d got %d want %d", n, d, n+1)
+		}
+		var x uint256
+		x.sub(&pow10w[n], &uint256{1})
+		if d := x.digits(); d != n {
+			t.Errorf("digits of 10**%d-1 got %d want %d", n, d, n)
+		}
+	}
+}